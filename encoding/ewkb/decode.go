@@ -0,0 +1,242 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// Decoder reads WKB or PostGIS EWKB geometries from r.
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decode reads a single geometry, discarding any SRID present.
+func (d *Decoder) Decode() (interface{}, error) {
+	g, _, err := d.decodeOne()
+	return g, err
+}
+
+// DecodeEWKB reads a single geometry along with its SRID, if any.
+func (d *Decoder) DecodeEWKB() (Geometry, error) {
+	g, srid, err := d.decodeOne()
+	return Geometry{SRID: srid, Geom: g}, err
+}
+
+func (d *Decoder) decodeOne() (interface{}, int, error) {
+	order, base, dims, srid, err := readHeader(d.r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	g, err := readGeometry(d.r, order, base, dims)
+	return g, srid, err
+}
+
+// readHeader reads a geometry's byte-order marker and type word, and, if
+// present, its SRID. It returns the base (Z/M/SRID-flag-stripped) OGC
+// type code and the coordinate dimension (2, 3 or 4) that type implies.
+func readHeader(r io.Reader) (order binary.ByteOrder, base uint32, dims, srid int, err error) {
+	var marker [1]byte
+	if _, err = io.ReadFull(r, marker[:]); err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	order = binary.LittleEndian
+	if marker[0] == wkbByteOrderXDR {
+		order = binary.BigEndian
+	}
+
+	var typ uint32
+	if err = binary.Read(r, order, &typ); err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	if typ&sridFlag != 0 {
+		var s uint32
+		if err = binary.Read(r, order, &s); err != nil {
+			return nil, 0, 0, 0, err
+		}
+		srid = int(s)
+	}
+
+	dims = 2
+	if typ&zFlag != 0 {
+		dims++
+	}
+	if typ&mFlag != 0 {
+		dims++
+	}
+
+	base = typ &^ (zFlag | mFlag | sridFlag)
+	// ISO SQL/MM producers encode Z/M/ZM via +1000/+2000/+3000 instead of
+	// the PostGIS high bits; fold those into the same dims accounting.
+	switch {
+	case base >= 3000:
+		base -= 3000
+		dims = 4
+	case base >= 2000:
+		base -= 2000
+		if dims < 3 {
+			dims = 3
+		}
+	case base >= 1000:
+		base -= 1000
+		if dims < 3 {
+			dims = 3
+		}
+	}
+
+	return order, base, dims, srid, nil
+}
+
+// maxPreallocCount caps how many elements a declared count is trusted to
+// preallocate for. Geometry.Scan feeds arbitrary external bytes into this
+// decoder, so a truncated or malicious input can claim a count near 4e9;
+// readers grow their slice with append instead of make([]T, n), so that
+// only ever costs a multi-gigabyte allocation if the input actually
+// contains that many elements, not merely claims to.
+const maxPreallocCount = 1 << 16
+
+func preallocHint(n uint32) int {
+	if n > maxPreallocCount {
+		return maxPreallocCount
+	}
+	return int(n)
+}
+
+func readGeometry(r io.Reader, order binary.ByteOrder, base uint32, dims int) (interface{}, error) {
+	switch base {
+	case typePoint:
+		return readPoint(r, order, dims)
+	case typeLineString:
+		return readLineString(r, order, dims)
+	case typePolygon:
+		return readPolygon(r, order, dims)
+	case typeMultiLineString:
+		return readMultiLineString(r, order, dims)
+	case typeMultiPolygon:
+		return readMultiPolygon(r, order, dims)
+	default:
+		return nil, fmt.Errorf("ewkb: unsupported geometry type code %d", base)
+	}
+}
+
+// readSubGeometry reads a nested element of a multi-geometry, which
+// carries its own byte-order marker and type word but never its own SRID.
+func readSubGeometry(r io.Reader) (interface{}, error) {
+	order, base, dims, _, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return readGeometry(r, order, base, dims)
+}
+
+func readPoint(r io.Reader, order binary.ByteOrder, dims int) (planar.Point, error) {
+	coords, err := readFloats(r, order, dims)
+	if err != nil {
+		return planar.Point{}, err
+	}
+	return planar.Point{coords[0], coords[1]}, nil
+}
+
+func readLineString(r io.Reader, order binary.ByteOrder, dims int) (planar.LineString, error) {
+	var n uint32
+	if err := binary.Read(r, order, &n); err != nil {
+		return nil, err
+	}
+
+	ls := make(planar.LineString, 0, preallocHint(n))
+	for i := uint32(0); i < n; i++ {
+		p, err := readPoint(r, order, dims)
+		if err != nil {
+			return nil, err
+		}
+		ls = append(ls, p)
+	}
+	return ls, nil
+}
+
+func readPolygon(r io.Reader, order binary.ByteOrder, dims int) (planar.Polygon, error) {
+	var n uint32
+	if err := binary.Read(r, order, &n); err != nil {
+		return nil, err
+	}
+
+	p := make(planar.Polygon, 0, preallocHint(n))
+	for i := uint32(0); i < n; i++ {
+		ring, err := readLineString(r, order, dims)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, ring)
+	}
+	return p, nil
+}
+
+func readMultiLineString(r io.Reader, order binary.ByteOrder, dims int) (planar.MultiLineString, error) {
+	var n uint32
+	if err := binary.Read(r, order, &n); err != nil {
+		return nil, err
+	}
+
+	mls := make(planar.MultiLineString, 0, preallocHint(n))
+	for i := uint32(0); i < n; i++ {
+		g, err := readSubGeometry(r)
+		if err != nil {
+			return nil, err
+		}
+		ls, ok := g.(planar.LineString)
+		if !ok {
+			return nil, fmt.Errorf("ewkb: expected LineString in MultiLineString, got %T", g)
+		}
+		mls = append(mls, ls)
+	}
+	return mls, nil
+}
+
+func readMultiPolygon(r io.Reader, order binary.ByteOrder, dims int) (planar.MultiPolygon, error) {
+	var n uint32
+	if err := binary.Read(r, order, &n); err != nil {
+		return nil, err
+	}
+
+	mp := make(planar.MultiPolygon, 0, preallocHint(n))
+	for i := uint32(0); i < n; i++ {
+		g, err := readSubGeometry(r)
+		if err != nil {
+			return nil, err
+		}
+		p, ok := g.(planar.Polygon)
+		if !ok {
+			return nil, fmt.Errorf("ewkb: expected Polygon in MultiPolygon, got %T", g)
+		}
+		mp = append(mp, p)
+	}
+	return mp, nil
+}
+
+// readFloats reads dims float64s, in planar's XY order, discarding any
+// Z/M components beyond the first two since planar.Point has no room for
+// them.
+func readFloats(r io.Reader, order binary.ByteOrder, dims int) ([2]float64, error) {
+	var out [2]float64
+	for i := 0; i < dims; i++ {
+		var bits uint64
+		if err := binary.Read(r, order, &bits); err != nil {
+			return out, err
+		}
+		if i < 2 {
+			out[i] = math.Float64frombits(bits)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,24 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// TestDecodeTruncatedHugeCountDoesNotOOM checks that a declared element
+// count near the uint32 max doesn't drive an up-front allocation sized
+// off that count: Decode should fail fast with an error once the input
+// runs out, not attempt to preallocate billions of elements first.
+func TestDecodeTruncatedHugeCountDoesNotOOM(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteByte(wkbByteOrderNDR)
+	binary.Write(&buf, binary.LittleEndian, uint32(typeLineString))
+	binary.Write(&buf, binary.LittleEndian, uint32(0xfffffffe)) // huge, bogus count
+	// no point data follows
+
+	_, err := NewDecoder(&buf).Decode()
+	if err == nil {
+		t.Fatal("Decode of a truncated huge-count LineString succeeded, want an error")
+	}
+}
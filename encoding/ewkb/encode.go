@@ -0,0 +1,181 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// hostByteOrder is used when a caller doesn't set one explicitly. Nearly
+// every platform this runs on is little-endian; SetByteOrder overrides it
+// for the rare case that matters.
+var hostByteOrder binary.ByteOrder = binary.LittleEndian
+
+// wkbByteOrderNDR/XDR are the single-byte markers WKB puts before the
+// geometry type word, matching the conventional (if confusingly named)
+// PostGIS terms for little- and big-endian.
+const (
+	wkbByteOrderNDR = 1
+	wkbByteOrderXDR = 0
+)
+
+// Encoder streams WKB, or EWKB when a non-zero SRID is supplied, to w.
+type Encoder struct {
+	w     io.Writer
+	order binary.ByteOrder
+}
+
+// NewEncoder returns an Encoder writing to w in the host byte order.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, order: hostByteOrder}
+}
+
+// SetByteOrder overrides the byte order used for subsequent Encode calls
+// and returns the Encoder for chaining.
+func (e *Encoder) SetByteOrder(order binary.ByteOrder) *Encoder {
+	e.order = order
+	return e
+}
+
+// Encode writes g as plain WKB, with no SRID.
+func (e *Encoder) Encode(g interface{}) error {
+	return e.encode(0, g)
+}
+
+// EncodeWithSRID writes g as PostGIS EWKB, embedding srid in the header.
+func (e *Encoder) EncodeWithSRID(srid int, g interface{}) error {
+	return e.encode(srid, g)
+}
+
+func (e *Encoder) encode(srid int, g interface{}) error {
+	var typ uint32
+	switch g.(type) {
+	case planar.Point:
+		typ = typePoint
+	case planar.LineString:
+		typ = typeLineString
+	case planar.Polygon:
+		typ = typePolygon
+	case planar.MultiLineString:
+		typ = typeMultiLineString
+	case planar.MultiPolygon:
+		typ = typeMultiPolygon
+	default:
+		return errUnsupported(g)
+	}
+
+	if srid != 0 {
+		typ |= sridFlag
+	}
+
+	if err := e.writeByteOrderMarker(); err != nil {
+		return err
+	}
+	if err := binary.Write(e.w, e.order, typ); err != nil {
+		return err
+	}
+	if srid != 0 {
+		if err := binary.Write(e.w, e.order, uint32(srid)); err != nil {
+			return err
+		}
+	}
+
+	switch v := g.(type) {
+	case planar.Point:
+		return e.writePoint(v)
+	case planar.LineString:
+		return e.writeLineString(v)
+	case planar.Polygon:
+		return e.writePolygon(v)
+	case planar.MultiLineString:
+		return e.writeMultiLineString(v)
+	case planar.MultiPolygon:
+		return e.writeMultiPolygon(v)
+	}
+
+	panic("ewkb: unreachable") // the first switch above already rejected anything else
+}
+
+func (e *Encoder) writeByteOrderMarker() error {
+	marker := byte(wkbByteOrderXDR)
+	if e.order == binary.LittleEndian {
+		marker = wkbByteOrderNDR
+	}
+	_, err := e.w.Write([]byte{marker})
+	return err
+}
+
+func (e *Encoder) writePoint(p planar.Point) error {
+	return e.writeFloats(p[0], p[1])
+}
+
+func (e *Encoder) writeLineString(ls planar.LineString) error {
+	if err := binary.Write(e.w, e.order, uint32(len(ls))); err != nil {
+		return err
+	}
+	for _, p := range ls {
+		if err := e.writeFloats(p[0], p[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writePolygon(p planar.Polygon) error {
+	if err := binary.Write(e.w, e.order, uint32(len(p))); err != nil {
+		return err
+	}
+	for _, ring := range p {
+		if err := e.writeLineString(ring); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writeMultiLineString(mls planar.MultiLineString) error {
+	if err := binary.Write(e.w, e.order, uint32(len(mls))); err != nil {
+		return err
+	}
+	for _, ls := range mls {
+		if err := e.writeByteOrderMarker(); err != nil {
+			return err
+		}
+		if err := binary.Write(e.w, e.order, uint32(typeLineString)); err != nil {
+			return err
+		}
+		if err := e.writeLineString(ls); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writeMultiPolygon(mp planar.MultiPolygon) error {
+	if err := binary.Write(e.w, e.order, uint32(len(mp))); err != nil {
+		return err
+	}
+	for _, p := range mp {
+		if err := e.writeByteOrderMarker(); err != nil {
+			return err
+		}
+		if err := binary.Write(e.w, e.order, uint32(typePolygon)); err != nil {
+			return err
+		}
+		if err := e.writePolygon(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) writeFloats(vals ...float64) error {
+	for _, v := range vals {
+		if err := binary.Write(e.w, e.order, math.Float64bits(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
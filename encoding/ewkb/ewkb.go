@@ -0,0 +1,39 @@
+// Package ewkb streams WKB and PostGIS-flavored EWKB for the geometry
+// types in the planar package: Point, LineString, MultiLineString,
+// Polygon and MultiPolygon. Unlike planar.Polygon.WKT, which only ever
+// produces a string, Encoder and Decoder work against an io.Writer/Reader
+// so large geometries don't need to round-trip through a []byte first.
+//
+// planar geometry is always two-dimensional, so encoding never writes a Z
+// or M component. Decoding accepts XYZ/XYZM input (as produced by other
+// tools) by reading and discarding the extra coordinates.
+package ewkb
+
+import "fmt"
+
+// geometry type codes, per the OGC WKB spec.
+const (
+	typePoint           = 1
+	typeLineString      = 2
+	typePolygon         = 3
+	typeMultiLineString = 5
+	typeMultiPolygon    = 6
+)
+
+// PostGIS's EWKB header flags, OR'd into the geometry type word.
+const (
+	zFlag    = 0x80000000
+	mFlag    = 0x40000000
+	sridFlag = 0x20000000
+)
+
+// Geometry pairs a decoded (or to-be-encoded) geometry with the SRID
+// carried in its EWKB representation. A zero SRID means none was present.
+type Geometry struct {
+	SRID int
+	Geom interface{}
+}
+
+func errUnsupported(g interface{}) error {
+	return fmt.Errorf("ewkb: unsupported geometry type %T", g)
+}
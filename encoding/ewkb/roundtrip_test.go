@@ -0,0 +1,137 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+func roundTrip(t *testing.T, order binary.ByteOrder, g interface{}) interface{} {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).SetByteOrder(order).Encode(g); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got, err := NewDecoder(&buf).Decode()
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	return got
+}
+
+// TestRoundTripGeometryTypes checks that every geometry type this package
+// supports survives an Encode/Decode round trip unchanged, in the default
+// (little-endian) byte order.
+func TestRoundTripGeometryTypes(t *testing.T) {
+	point := planar.Point{1, 2}
+	line := planar.LineString{{0, 0}, {1, 1}, {2, 0}}
+	polygon := planar.Polygon{
+		planar.LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		planar.LineString{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	}
+	multiLine := planar.MultiLineString{
+		{{0, 0}, {1, 1}},
+		{{2, 2}, {3, 3}, {4, 4}},
+	}
+	multiPolygon := planar.MultiPolygon{
+		{planar.LineString{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+		{planar.LineString{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}},
+	}
+
+	cases := []struct {
+		name string
+		geom interface{}
+	}{
+		{"Point", point},
+		{"LineString", line},
+		{"Polygon", polygon},
+		{"MultiLineString", multiLine},
+		{"MultiPolygon", multiPolygon},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := roundTrip(t, binary.LittleEndian, c.geom)
+			if !reflect.DeepEqual(got, c.geom) {
+				t.Fatalf("got %#v, want %#v", got, c.geom)
+			}
+		})
+	}
+}
+
+// TestRoundTripPreservesSRID checks that EncodeWithSRID/DecodeEWKB carry
+// the SRID through unchanged, and that a zero SRID round-trips as zero.
+func TestRoundTripPreservesSRID(t *testing.T) {
+	point := planar.Point{1, 2}
+
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).EncodeWithSRID(4326, point); err != nil {
+		t.Fatalf("EncodeWithSRID returned error: %v", err)
+	}
+
+	got, err := NewDecoder(&buf).DecodeEWKB()
+	if err != nil {
+		t.Fatalf("DecodeEWKB returned error: %v", err)
+	}
+	if got.SRID != 4326 {
+		t.Fatalf("got SRID %d, want 4326", got.SRID)
+	}
+	if !reflect.DeepEqual(got.Geom, point) {
+		t.Fatalf("got geom %#v, want %#v", got.Geom, point)
+	}
+
+	buf.Reset()
+	if err := NewEncoder(&buf).EncodeWithSRID(0, point); err != nil {
+		t.Fatalf("EncodeWithSRID returned error: %v", err)
+	}
+	got, err = NewDecoder(&buf).DecodeEWKB()
+	if err != nil {
+		t.Fatalf("DecodeEWKB returned error: %v", err)
+	}
+	if got.SRID != 0 {
+		t.Fatalf("got SRID %d, want 0", got.SRID)
+	}
+}
+
+// TestRoundTripBigEndian checks that a big-endian (XDR) encoded geometry
+// decodes correctly, since Decode must honor the byte-order marker rather
+// than assuming the host order used to encode it.
+func TestRoundTripBigEndian(t *testing.T) {
+	polygon := planar.Polygon{
+		planar.LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+	}
+
+	got := roundTrip(t, binary.BigEndian, polygon)
+	if !reflect.DeepEqual(got, polygon) {
+		t.Fatalf("got %#v, want %#v", got, polygon)
+	}
+}
+
+// TestGeometryValueScanRoundTrip checks the database/sql path: Value
+// hex-encodes an EWKB Geometry and Scan decodes that same hex string back
+// into an equal Geometry, as a driver would round-trip a geometry column.
+func TestGeometryValueScanRoundTrip(t *testing.T) {
+	want := Geometry{SRID: 4326, Geom: planar.LineString{{0, 0}, {1, 1}, {2, 0}}}
+
+	value, err := want.Value()
+	if err != nil {
+		t.Fatalf("Value returned error: %v", err)
+	}
+
+	var got Geometry
+	if err := got.Scan(value); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	if got.SRID != want.SRID {
+		t.Fatalf("got SRID %d, want %d", got.SRID, want.SRID)
+	}
+	if !reflect.DeepEqual(got.Geom, want.Geom) {
+		t.Fatalf("got geom %#v, want %#v", got.Geom, want.Geom)
+	}
+}
@@ -0,0 +1,50 @@
+package ewkb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// Value implements driver.Valuer, encoding g as a hex-encoded EWKB string
+// the way lib/pq and pgx expect for a geometry column, e.g.
+//
+//	db.Exec(`INSERT INTO places (geom) VALUES ($1)`, ewkb.Geometry{SRID: 4326, Geom: point})
+func (g Geometry) Value() (driver.Value, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).EncodeWithSRID(g.SRID, g.Geom); err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// Scan implements sql.Scanner, accepting the hex-encoded EWKB string (or
+// raw EWKB bytes) a database driver returns for a geometry column.
+func (g *Geometry) Scan(src interface{}) error {
+	var raw []byte
+
+	switch v := src.(type) {
+	case nil:
+		*g = Geometry{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("ewkb: cannot scan %T into Geometry", src)
+	}
+
+	if decoded, err := hex.DecodeString(string(raw)); err == nil {
+		raw = decoded
+	}
+
+	decoded, err := NewDecoder(bytes.NewReader(raw)).DecodeEWKB()
+	if err != nil {
+		return err
+	}
+
+	*g = decoded
+	return nil
+}
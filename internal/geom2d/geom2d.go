@@ -0,0 +1,83 @@
+// Package geom2d holds the 2D segment-intersection math shared by
+// planar, planar/validate and simplify. It works in raw float64 pairs
+// rather than any package's Point type so all three can depend on it
+// without an import cycle back to planar.
+package geom2d
+
+// Cross returns twice the signed area of the triangle a, b, c: positive
+// when c is left of the directed line a->b, negative when it's right,
+// zero when the three points are collinear.
+func Cross(ax, ay, bx, by, cx, cy float64) float64 {
+	return (bx-ax)*(cy-ay) - (by-ay)*(cx-ax)
+}
+
+// OnSegment reports whether p, already known to be collinear with a-b,
+// falls within a-b's bounding box (and therefore on the segment itself).
+func OnSegment(ax, ay, bx, by, px, py float64) bool {
+	minX, maxX := ax, bx
+	if minX > maxX {
+		minX, maxX = maxX, minX
+	}
+	minY, maxY := ay, by
+	if minY > maxY {
+		minY, maxY = maxY, minY
+	}
+	return px >= minX && px <= maxX && py >= minY && py <= maxY
+}
+
+// SegmentsCross reports whether segments p1-p2 and p3-p4 properly cross:
+// each segment's endpoints lie strictly on opposite sides of the other.
+// Touching endpoints and collinear overlap are not considered a cross;
+// use SegmentsCrossOrTouch when those should count.
+func SegmentsCross(p1x, p1y, p2x, p2y, p3x, p3y, p4x, p4y float64) bool {
+	d1 := Cross(p3x, p3y, p4x, p4y, p1x, p1y)
+	d2 := Cross(p3x, p3y, p4x, p4y, p2x, p2y)
+	d3 := Cross(p1x, p1y, p2x, p2y, p3x, p3y)
+	d4 := Cross(p1x, p1y, p2x, p2y, p4x, p4y)
+
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// SegmentsCrossOrTouch is SegmentsCross extended to also report a touching
+// endpoint or collinear overlap, i.e. any shared point between the two
+// segments rather than only a proper crossing.
+func SegmentsCrossOrTouch(p1x, p1y, p2x, p2y, p3x, p3y, p4x, p4y float64) bool {
+	d1 := Cross(p3x, p3y, p4x, p4y, p1x, p1y)
+	d2 := Cross(p3x, p3y, p4x, p4y, p2x, p2y)
+	d3 := Cross(p1x, p1y, p2x, p2y, p3x, p3y)
+	d4 := Cross(p1x, p1y, p2x, p2y, p4x, p4y)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	if d1 == 0 && OnSegment(p3x, p3y, p4x, p4y, p1x, p1y) {
+		return true
+	}
+	if d2 == 0 && OnSegment(p3x, p3y, p4x, p4y, p2x, p2y) {
+		return true
+	}
+	if d3 == 0 && OnSegment(p1x, p1y, p2x, p2y, p3x, p3y) {
+		return true
+	}
+	if d4 == 0 && OnSegment(p1x, p1y, p2x, p2y, p4x, p4y) {
+		return true
+	}
+
+	return false
+}
+
+// PointInTriangle reports whether p lies inside (or on the boundary of)
+// the triangle a, b, c, regardless of the triangle's winding direction.
+func PointInTriangle(ax, ay, bx, by, cx, cy, px, py float64) bool {
+	d1 := Cross(ax, ay, bx, by, px, py)
+	d2 := Cross(bx, by, cx, cy, px, py)
+	d3 := Cross(cx, cy, ax, ay, px, py)
+
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+
+	return !(hasNeg && hasPos)
+}
@@ -0,0 +1,246 @@
+// Package rtree is a minimal static R-tree, bulk-loaded once with the
+// sort-tile-recursive (STR) algorithm and never updated afterwards. It
+// works in raw float64 bounds rather than any package's Point or Rect
+// type, and items are identified by the index passed to New, so any
+// package can build one over its own per-item bounding boxes without an
+// import cycle back to planar.
+package rtree
+
+import "math"
+
+// maxEntries is the branching factor used when bulk-loading the tree.
+const maxEntries = 8
+
+// Bound is an axis-aligned bounding box.
+type Bound struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// BoundOfSegment returns the bounding box of the segment (ax,ay)-(bx,by).
+func BoundOfSegment(ax, ay, bx, by float64) Bound {
+	bound := Bound{MinX: ax, MaxX: ax, MinY: ay, MaxY: ay}
+	if bx < bound.MinX {
+		bound.MinX = bx
+	}
+	if bx > bound.MaxX {
+		bound.MaxX = bx
+	}
+	if by < bound.MinY {
+		bound.MinY = by
+	}
+	if by > bound.MaxY {
+		bound.MaxY = by
+	}
+	return bound
+}
+
+// Expand returns the smallest bound containing both b and o.
+func (b Bound) Expand(o Bound) Bound {
+	if o.MinX < b.MinX {
+		b.MinX = o.MinX
+	}
+	if o.MinY < b.MinY {
+		b.MinY = o.MinY
+	}
+	if o.MaxX > b.MaxX {
+		b.MaxX = o.MaxX
+	}
+	if o.MaxY > b.MaxY {
+		b.MaxY = o.MaxY
+	}
+	return b
+}
+
+// Intersects reports whether b and o overlap (including touching).
+func (b Bound) Intersects(o Bound) bool {
+	return b.MinX <= o.MaxX && b.MaxX >= o.MinX && b.MinY <= o.MaxY && b.MaxY >= o.MinY
+}
+
+// Distance returns the (non-negative) distance from (px,py) to the
+// closest point of b, or 0 if the point is inside it.
+func (b Bound) Distance(px, py float64) float64 {
+	dx := 0.0
+	if px < b.MinX {
+		dx = b.MinX - px
+	} else if px > b.MaxX {
+		dx = px - b.MaxX
+	}
+
+	dy := 0.0
+	if py < b.MinY {
+		dy = b.MinY - py
+	} else if py > b.MaxY {
+		dy = py - b.MaxY
+	}
+
+	return math.Hypot(dx, dy)
+}
+
+// node is either a leaf, holding a single item index, or an internal node
+// holding children. Leaves are identified by children == nil.
+type node struct {
+	bound    Bound
+	item     int
+	children []*node
+}
+
+// Tree is a static R-tree over a fixed set of item bounds, bulk-loaded
+// once and reused across many queries.
+type Tree struct {
+	root *node
+}
+
+// New bulk-loads a Tree over the given item bounds. bounds[i] is the
+// bound of item i, which Search and Nearest report back by that index.
+func New(bounds []Bound) *Tree {
+	leaves := make([]*node, len(bounds))
+	for i, b := range bounds {
+		leaves[i] = &node{bound: b, item: i}
+	}
+
+	return &Tree{root: strBuild(leaves)}
+}
+
+// strBuild recursively groups nodes into maxEntries-sized parents,
+// sorting by x then tiling by y at each level, until a single root remains.
+func strBuild(nodes []*node) *node {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+
+	sortNodesByX(nodes)
+
+	tiles := int(math.Ceil(math.Sqrt(float64(ceilDiv(len(nodes), maxEntries)))))
+
+	var parents []*node
+	perTile := ceilDiv(len(nodes), tiles)
+	for i := 0; i < len(nodes); i += perTile {
+		end := i + perTile
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+
+		tile := append([]*node(nil), nodes[i:end]...)
+		sortNodesByY(tile)
+
+		for j := 0; j < len(tile); j += maxEntries {
+			k := j + maxEntries
+			if k > len(tile) {
+				k = len(tile)
+			}
+
+			group := tile[j:k]
+			parents = append(parents, groupNodes(group))
+		}
+	}
+
+	return strBuild(parents)
+}
+
+func groupNodes(group []*node) *node {
+	if len(group) == 1 {
+		return group[0]
+	}
+
+	bound := group[0].bound
+	for _, n := range group[1:] {
+		bound = bound.Expand(n.bound)
+	}
+
+	return &node{bound: bound, item: -1, children: group}
+}
+
+func sortNodesByX(nodes []*node) {
+	sortNodes(nodes, func(n *node) float64 { return (n.bound.MinX + n.bound.MaxX) / 2 })
+}
+
+func sortNodesByY(nodes []*node) {
+	sortNodes(nodes, func(n *node) float64 { return (n.bound.MinY + n.bound.MaxY) / 2 })
+}
+
+// sortNodes is a small insertion sort, which avoids sort.Slice's
+// reflection overhead on the short slices it mostly sees (a node's
+// children, at most maxEntries). strBuild also uses it for each tile and
+// for the x order of a whole level, where its cost grows with the square
+// of the slice length.
+func sortNodes(nodes []*node, key func(*node) float64) {
+	for i := 1; i < len(nodes); i++ {
+		n := nodes[i]
+		k := key(n)
+		j := i - 1
+		for j >= 0 && key(nodes[j]) > k {
+			nodes[j+1] = nodes[j]
+			j--
+		}
+		nodes[j+1] = n
+	}
+}
+
+func ceilDiv(a, b int) int {
+	if b <= 0 {
+		return a
+	}
+	return (a + b - 1) / b
+}
+
+// Search visits every indexed item whose bound intersects q.
+func (t *Tree) Search(q Bound, visit func(item int)) {
+	if t == nil || t.root == nil {
+		return
+	}
+	searchNode(t.root, q, visit)
+}
+
+func searchNode(n *node, q Bound, visit func(item int)) {
+	if !n.bound.Intersects(q) {
+		return
+	}
+
+	if n.children == nil {
+		visit(n.item)
+		return
+	}
+
+	for _, c := range n.children {
+		searchNode(c, q, visit)
+	}
+}
+
+// Nearest returns the index of the item closest to (px,py) by true
+// distance, as reported by trueDist. A node's bound.Distance is only ever
+// used to prune subtrees (it's a valid lower bound on the true distance
+// of anything inside the bound); the final answer always comes from
+// trueDist, never from the bound itself.
+func (t *Tree) Nearest(px, py float64, trueDist func(item int) float64) (item int, dist float64) {
+	item = -1
+	dist = math.Inf(1)
+	if t == nil || t.root == nil {
+		return item, dist
+	}
+
+	nearestNode(t.root, px, py, trueDist, &item, &dist)
+	return item, dist
+}
+
+func nearestNode(n *node, px, py float64, trueDist func(item int) float64, bestItem *int, bestDist *float64) {
+	if n.bound.Distance(px, py) > *bestDist {
+		return
+	}
+
+	if n.children == nil {
+		if d := trueDist(n.item); d < *bestDist {
+			*bestDist = d
+			*bestItem = n.item
+		}
+		return
+	}
+
+	// visit the closer children first (by bbox distance, a lower bound on
+	// true distance) so bestDist tightens quickly and prunes more of the
+	// remaining subtree.
+	children := append([]*node(nil), n.children...)
+	sortNodes(children, func(c *node) float64 { return c.bound.Distance(px, py) })
+	for _, c := range children {
+		nearestNode(c, px, py, trueDist, bestItem, bestDist)
+	}
+}
@@ -0,0 +1,245 @@
+package osm
+
+import (
+	"fmt"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// AssemblyReport carries the diagnostics a caller needs to decide whether
+// to accept a partially-built multipolygon.
+type AssemblyReport struct {
+	// MissingWays are member way IDs that weren't found in the ways map.
+	MissingWays []int64
+	// UnmatchedOuter/UnmatchedInner list, for each outer/inner way chain
+	// that never closed into a ring, the IDs of the ways in that chain.
+	UnmatchedOuter [][]int64
+	UnmatchedInner [][]int64
+	// OrphanInners counts inner rings that didn't fit inside any outer ring.
+	OrphanInners int
+}
+
+// MultiPolygon assembles relation's outer/inner way members into a valid
+// planar.MultiPolygon, following the classic imposm3 ring-assembly
+// algorithm: partition members into outer/inner rings by role (defaulting
+// to outer when unspecified), greedily stitch each group's ways into
+// closed rings by matching endpoint node IDs, then assign each inner ring
+// to the smallest enclosing outer ring. ways must contain every way
+// referenced by relation's members; entries found in MissingWays or
+// UnmatchedOuter/Inner in the returned report were skipped rather than
+// causing the whole assembly to fail.
+func MultiPolygon(relation *Relation, ways map[int64]*Way) (planar.MultiPolygon, *AssemblyReport, error) {
+	if relation.Tags["type"] != "multipolygon" {
+		return nil, nil, fmt.Errorf("osm: relation %d is not type=multipolygon", relation.ID)
+	}
+
+	report := &AssemblyReport{}
+
+	var outerWays, innerWays []*Way
+	for _, m := range relation.Members {
+		if m.Type != TypeWay {
+			continue
+		}
+
+		w, ok := ways[m.Ref]
+		if !ok {
+			report.MissingWays = append(report.MissingWays, m.Ref)
+			continue
+		}
+
+		if m.Role == "inner" {
+			innerWays = append(innerWays, w)
+		} else {
+			// "outer" and the unspecified/empty role both default to outer
+			outerWays = append(outerWays, w)
+		}
+	}
+
+	outerRings, unmatched := stitchRings(outerWays)
+	report.UnmatchedOuter = unmatched
+
+	innerRings, unmatched := stitchRings(innerWays)
+	report.UnmatchedInner = unmatched
+
+	mp := assemblePolygons(outerRings, innerRings, report)
+	return mp, report, nil
+}
+
+// wayFragment is a run of one or more ways stitched end-to-end, kept
+// mutable while stitchRings tries to close it into a ring.
+type wayFragment struct {
+	wayIDs []int64
+	nodes  []WayNode
+}
+
+func (f *wayFragment) closed() bool {
+	return len(f.nodes) >= 2 && f.nodes[0].ID == f.nodes[len(f.nodes)-1].ID
+}
+
+// stitchRings greedily joins way fragments that share an endpoint node ID,
+// reversing either side as needed, until no more joins are possible. Every
+// fragment that ends up closed becomes a ring; the rest are reported as
+// unmatched way chains.
+func stitchRings(ways []*Way) (rings []planar.LineString, unmatched [][]int64) {
+	var open []*wayFragment
+	for _, w := range ways {
+		if len(w.Nodes) < 2 {
+			continue
+		}
+		open = append(open, &wayFragment{wayIDs: []int64{w.ID}, nodes: append([]WayNode(nil), w.Nodes...)})
+	}
+
+	for {
+		joinedAny := false
+
+		for i := 0; i < len(open); i++ {
+			if open[i].closed() {
+				continue
+			}
+
+			for j := 0; j < len(open); j++ {
+				if i == j || open[j].closed() {
+					continue
+				}
+
+				if joined, ok := joinFragments(open[i], open[j]); ok {
+					open[i] = joined
+
+					open = append(open[:j], open[j+1:]...)
+					if j < i {
+						i--
+					}
+
+					joinedAny = true
+					break
+				}
+			}
+
+			if joinedAny {
+				break
+			}
+		}
+
+		if !joinedAny {
+			break
+		}
+	}
+
+	for _, f := range open {
+		if f.closed() && len(f.nodes) >= 4 {
+			ring := make(planar.LineString, len(f.nodes))
+			for i, n := range f.nodes {
+				ring[i] = planar.Point{n.Lon, n.Lat}
+			}
+			rings = append(rings, ring)
+		} else {
+			unmatched = append(unmatched, f.wayIDs)
+		}
+	}
+
+	return rings, unmatched
+}
+
+func joinFragments(f, g *wayFragment) (*wayFragment, bool) {
+	fStart, fEnd := f.nodes[0].ID, f.nodes[len(f.nodes)-1].ID
+	gStart, gEnd := g.nodes[0].ID, g.nodes[len(g.nodes)-1].ID
+
+	switch {
+	case fEnd == gStart:
+		return &wayFragment{
+			wayIDs: concatInt64(f.wayIDs, g.wayIDs),
+			nodes:  concatNodes(f.nodes, g.nodes[1:]),
+		}, true
+	case fEnd == gEnd:
+		return &wayFragment{
+			wayIDs: concatInt64(f.wayIDs, g.wayIDs),
+			nodes:  concatNodes(f.nodes, reverseNodes(g.nodes)[1:]),
+		}, true
+	case fStart == gEnd:
+		return &wayFragment{
+			wayIDs: concatInt64(g.wayIDs, f.wayIDs),
+			nodes:  concatNodes(g.nodes, f.nodes[1:]),
+		}, true
+	case fStart == gStart:
+		return &wayFragment{
+			wayIDs: concatInt64(reverseInt64(f.wayIDs), g.wayIDs),
+			nodes:  concatNodes(reverseNodes(f.nodes), g.nodes[1:]),
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func concatNodes(a, b []WayNode) []WayNode {
+	out := make([]WayNode, 0, len(a)+len(b))
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+func concatInt64(a, b []int64) []int64 {
+	out := make([]int64, 0, len(a)+len(b))
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+func reverseNodes(nodes []WayNode) []WayNode {
+	out := make([]WayNode, len(nodes))
+	for i, n := range nodes {
+		out[len(nodes)-1-i] = n
+	}
+	return out
+}
+
+func reverseInt64(ids []int64) []int64 {
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[len(ids)-1-i] = id
+	}
+	return out
+}
+
+// assemblePolygons pairs each inner ring with the smallest outer ring
+// that contains one of its vertices, emitting one Polygon per outer ring
+// plus its assigned inners.
+func assemblePolygons(outerRings, innerRings []planar.LineString, report *AssemblyReport) planar.MultiPolygon {
+	result := make(planar.MultiPolygon, len(outerRings))
+	for i, ring := range outerRings {
+		result[i] = planar.Polygon{ring}
+	}
+
+	for _, hole := range innerRings {
+		best := -1
+		bestArea := 0.0
+
+		for i, ring := range outerRings {
+			if !(planar.Polygon{ring}).Contains(hole[0]) {
+				continue
+			}
+
+			area := ringArea(ring)
+			if best == -1 || area < bestArea {
+				best, bestArea = i, area
+			}
+		}
+
+		if best == -1 {
+			report.OrphanInners++
+			continue
+		}
+
+		result[best] = append(result[best], hole)
+	}
+
+	return result
+}
+
+func ringArea(ring planar.LineString) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	if area < 0 {
+		area = -area
+	}
+	return area / 2
+}
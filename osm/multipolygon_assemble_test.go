@@ -0,0 +1,138 @@
+package osm
+
+import "testing"
+
+func squareWay(id int64, base int64, x0, y0, x1, y1 float64) *Way {
+	return &Way{
+		ID: id,
+		Nodes: []WayNode{
+			{ID: base, Lon: x0, Lat: y0},
+			{ID: base + 1, Lon: x1, Lat: y0},
+			{ID: base + 2, Lon: x1, Lat: y1},
+			{ID: base + 3, Lon: x0, Lat: y1},
+			{ID: base, Lon: x0, Lat: y0},
+		},
+	}
+}
+
+func multipolygonRelation(members ...Member) *Relation {
+	return &Relation{
+		ID:      1,
+		Tags:    map[string]string{"type": "multipolygon"},
+		Members: members,
+	}
+}
+
+// TestMultiPolygonAssignsInnerToEnclosingOuter checks the basic case: a
+// single outer ring with one inner ring inside it ends up as one Polygon
+// with the inner as its hole.
+func TestMultiPolygonAssignsInnerToEnclosingOuter(t *testing.T) {
+	outer := squareWay(1, 100, 0, 0, 10, 10)
+	inner := squareWay(2, 200, 2, 2, 4, 4)
+
+	ways := map[int64]*Way{1: outer, 2: inner}
+	relation := multipolygonRelation(
+		Member{Type: TypeWay, Ref: 1, Role: "outer"},
+		Member{Type: TypeWay, Ref: 2, Role: "inner"},
+	)
+
+	mp, report, err := MultiPolygon(relation, ways)
+	if err != nil {
+		t.Fatalf("MultiPolygon returned error: %v", err)
+	}
+	if len(mp) != 1 {
+		t.Fatalf("got %d polygons, want 1", len(mp))
+	}
+	if len(mp[0]) != 2 {
+		t.Fatalf("got %d rings in the polygon, want 2 (shell + hole)", len(mp[0]))
+	}
+	if report.OrphanInners != 0 {
+		t.Fatalf("got %d orphan inners, want 0", report.OrphanInners)
+	}
+}
+
+// TestMultiPolygonAssignsInnerToSmallestEnclosingOuter checks that when
+// two outer rings both contain the inner ring's vertex (nested, not
+// side-by-side), the inner is attached to the smaller, more specific one.
+func TestMultiPolygonAssignsInnerToSmallestEnclosingOuter(t *testing.T) {
+	bigOuter := squareWay(1, 100, 0, 0, 20, 20)
+	smallOuter := squareWay(2, 200, 5, 5, 15, 15)
+	inner := squareWay(3, 300, 8, 8, 10, 10)
+
+	ways := map[int64]*Way{1: bigOuter, 2: smallOuter, 3: inner}
+	relation := multipolygonRelation(
+		Member{Type: TypeWay, Ref: 1, Role: "outer"},
+		Member{Type: TypeWay, Ref: 2, Role: "outer"},
+		Member{Type: TypeWay, Ref: 3, Role: "inner"},
+	)
+
+	mp, report, err := MultiPolygon(relation, ways)
+	if err != nil {
+		t.Fatalf("MultiPolygon returned error: %v", err)
+	}
+	if len(mp) != 2 {
+		t.Fatalf("got %d polygons, want 2", len(mp))
+	}
+
+	var withHoleCount int
+	for _, p := range mp {
+		if len(p) == 2 {
+			withHoleCount++
+		}
+	}
+	if withHoleCount != 1 {
+		t.Fatalf("got %d polygons with a hole, want exactly 1", withHoleCount)
+	}
+	if report.OrphanInners != 0 {
+		t.Fatalf("got %d orphan inners, want 0", report.OrphanInners)
+	}
+}
+
+// TestMultiPolygonOrphanInner checks that an inner ring that doesn't fall
+// inside any outer ring is counted in OrphanInners rather than attached
+// to the wrong polygon or silently dropped.
+func TestMultiPolygonOrphanInner(t *testing.T) {
+	outer := squareWay(1, 100, 0, 0, 10, 10)
+	strayInner := squareWay(2, 200, 50, 50, 60, 60)
+
+	ways := map[int64]*Way{1: outer, 2: strayInner}
+	relation := multipolygonRelation(
+		Member{Type: TypeWay, Ref: 1, Role: "outer"},
+		Member{Type: TypeWay, Ref: 2, Role: "inner"},
+	)
+
+	mp, report, err := MultiPolygon(relation, ways)
+	if err != nil {
+		t.Fatalf("MultiPolygon returned error: %v", err)
+	}
+	if len(mp) != 1 || len(mp[0]) != 1 {
+		t.Fatalf("got %v, want a single polygon with no holes", mp)
+	}
+	if report.OrphanInners != 1 {
+		t.Fatalf("got %d orphan inners, want 1", report.OrphanInners)
+	}
+}
+
+// TestMultiPolygonMissingWay checks that a member referencing a way ID
+// absent from the ways map is reported in MissingWays rather than
+// causing MultiPolygon to fail outright.
+func TestMultiPolygonMissingWay(t *testing.T) {
+	outer := squareWay(1, 100, 0, 0, 10, 10)
+
+	ways := map[int64]*Way{1: outer}
+	relation := multipolygonRelation(
+		Member{Type: TypeWay, Ref: 1, Role: "outer"},
+		Member{Type: TypeWay, Ref: 999, Role: "inner"},
+	)
+
+	mp, report, err := MultiPolygon(relation, ways)
+	if err != nil {
+		t.Fatalf("MultiPolygon returned error: %v", err)
+	}
+	if len(mp) != 1 {
+		t.Fatalf("got %d polygons, want 1", len(mp))
+	}
+	if len(report.MissingWays) != 1 || report.MissingWays[0] != 999 {
+		t.Fatalf("got MissingWays %v, want [999]", report.MissingWays)
+	}
+}
@@ -0,0 +1,38 @@
+package osm
+
+import "testing"
+
+// TestStitchRingsPreservesAlreadyClosedRing checks that an already-closed
+// ring isn't spliced onto an unrelated fragment just because they share a
+// node ID, which happens routinely at OSM junction nodes.
+func TestStitchRingsPreservesAlreadyClosedRing(t *testing.T) {
+	square := &Way{
+		ID: 1,
+		Nodes: []WayNode{
+			{ID: 100, Lon: 0, Lat: 0},
+			{ID: 101, Lon: 10, Lat: 0},
+			{ID: 102, Lon: 10, Lat: 10},
+			{ID: 103, Lon: 0, Lat: 10},
+			{ID: 100, Lon: 0, Lat: 0},
+		},
+	}
+	spur := &Way{
+		ID: 2,
+		Nodes: []WayNode{
+			{ID: 100, Lon: 0, Lat: 0},
+			{ID: 200, Lon: 20, Lat: 20},
+		},
+	}
+
+	rings, unmatched := stitchRings([]*Way{square, spur})
+
+	if len(rings) != 1 {
+		t.Fatalf("got %d rings, want 1", len(rings))
+	}
+	if len(rings[0]) != 5 {
+		t.Fatalf("ring has %d points, want 5", len(rings[0]))
+	}
+	if len(unmatched) != 1 || len(unmatched[0]) != 1 || unmatched[0][0] != 2 {
+		t.Fatalf("unmatched = %v, want [[2]]", unmatched)
+	}
+}
@@ -0,0 +1,43 @@
+// Package osm provides minimal OSM element types alongside helpers for
+// turning them into orb/planar geometries.
+package osm
+
+// MemberType is the kind of element an OSM relation member refers to.
+type MemberType string
+
+// The element types a Member can reference.
+const (
+	TypeNode     MemberType = "node"
+	TypeWay      MemberType = "way"
+	TypeRelation MemberType = "relation"
+)
+
+// WayNode is a node reference within a Way, already resolved to its
+// coordinates so a Way's geometry can be built without a separate lookup.
+type WayNode struct {
+	ID  int64
+	Lat float64
+	Lon float64
+}
+
+// Way is an ordered sequence of nodes.
+type Way struct {
+	ID    int64
+	Nodes []WayNode
+	Tags  map[string]string
+}
+
+// Member is one element referenced by a Relation, with the role it plays
+// within it, e.g. "outer"/"inner" for a type=multipolygon relation.
+type Member struct {
+	Type MemberType
+	Ref  int64
+	Role string
+}
+
+// Relation groups other elements under a shared set of tags.
+type Relation struct {
+	ID      int64
+	Members []Member
+	Tags    map[string]string
+}
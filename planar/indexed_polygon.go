@@ -0,0 +1,204 @@
+package planar
+
+import (
+	"math"
+
+	"github.com/planetfederal/orb/internal/geom2d"
+	"github.com/planetfederal/orb/internal/rtree"
+)
+
+// indexedRingThreshold is the smallest ring size worth indexing. Rings
+// with fewer points fall back to the plain linear scan since building and
+// querying an R-tree would cost more than it saves.
+const indexedRingThreshold = 32
+
+// IndexedPolygon wraps a Polygon with a per-ring R-tree over its edge
+// segments, so repeated Contains, Intersects and NearestEdge queries run
+// in O(log N + k) instead of scanning every edge. Build once and reuse it
+// across many queries, e.g. tile cover or batch geocoding. The index is
+// read-only: mutating the underlying Polygon after indexing invalidates it.
+type IndexedPolygon struct {
+	polygon Polygon
+	rings   []indexedRing
+}
+
+type indexedRing struct {
+	ls   LineString
+	tree *rtree.Tree // nil for rings below indexedRingThreshold
+}
+
+// NewIndexedPolygon builds a spatial index over the rings of p.
+func NewIndexedPolygon(p Polygon) *IndexedPolygon {
+	rings := make([]indexedRing, len(p))
+	for i, ls := range p {
+		rings[i] = newIndexedRing(ls)
+	}
+
+	return &IndexedPolygon{polygon: p, rings: rings}
+}
+
+func newIndexedRing(ls LineString) indexedRing {
+	if len(ls) < indexedRingThreshold {
+		return indexedRing{ls: ls}
+	}
+
+	bounds := make([]rtreeBound, len(ls)-1)
+	for i := 0; i < len(ls)-1; i++ {
+		bounds[i] = rtreeBoundOfSegment(ls[i], ls[i+1])
+	}
+
+	return indexedRing{ls: ls, tree: newRTree(bounds)}
+}
+
+// Contains checks if the point is within the indexed polygon. Points on
+// the boundary are considered in, matching Polygon.Contains.
+func (ip *IndexedPolygon) Contains(point Point) bool {
+	if !ip.rings[0].ls.Bound().Contains(point) {
+		return false
+	}
+
+	if !ip.rings[0].contains(point) {
+		return false
+	}
+
+	for i := 1; i < len(ip.rings); i++ {
+		if ip.rings[i].contains(point) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ir indexedRing) contains(point Point) bool {
+	if ir.tree == nil {
+		return lineStringContains(ir.ls, point)
+	}
+
+	// rayIntersect (see lineStringContains) only ever returns true for an
+	// edge whose x-range straddles point's x, regardless of the edge's y
+	// extent, so that's the only axis the tree can safely prune on.
+	q := rtreeBound{MinX: point[0], MaxX: point[0], MinY: math.Inf(-1), MaxY: math.Inf(1)}
+
+	c, on := false, false
+	ir.tree.Search(q, func(seg int) {
+		if on {
+			return
+		}
+
+		inter, edgeOn := rayIntersect(point, ir.ls[seg], ir.ls[seg+1])
+		if edgeOn {
+			on = true
+			return
+		}
+
+		if inter {
+			c = !c
+		}
+	})
+
+	return on || c
+}
+
+// Intersects reports whether any segment of ls crosses or touches an edge
+// of the polygon's rings.
+func (ip *IndexedPolygon) Intersects(ls LineString) bool {
+	for i := 0; i < len(ls)-1; i++ {
+		q := rtreeBoundOfSegment(ls[i], ls[i+1])
+
+		for _, ring := range ip.rings {
+			if ring.intersectsSegment(q, ls[i], ls[i+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (ir indexedRing) intersectsSegment(q rtreeBound, a, b Point) bool {
+	if ir.tree == nil {
+		for i := 0; i < len(ir.ls)-1; i++ {
+			if segmentsIntersect(a, b, ir.ls[i], ir.ls[i+1]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	found := false
+	ir.tree.Search(q, func(seg int) {
+		if found {
+			return
+		}
+		if segmentsIntersect(a, b, ir.ls[seg], ir.ls[seg+1]) {
+			found = true
+		}
+	})
+
+	return found
+}
+
+// NearestEdge returns the closest point on the polygon's boundary to the
+// given point, along with the distance between them.
+func (ip *IndexedPolygon) NearestEdge(point Point) (nearest Point, distance float64) {
+	distance = math.Inf(1)
+
+	for _, ring := range ip.rings {
+		p, d := ring.nearestEdge(point)
+		if d < distance {
+			nearest, distance = p, d
+		}
+	}
+
+	return nearest, distance
+}
+
+func (ir indexedRing) nearestEdge(point Point) (Point, float64) {
+	if ir.tree == nil {
+		best := math.Inf(1)
+		var nearest Point
+		for i := 0; i < len(ir.ls)-1; i++ {
+			p, d := closestPointOnSegment(point, ir.ls[i], ir.ls[i+1])
+			if d < best {
+				best, nearest = d, p
+			}
+		}
+		return nearest, best
+	}
+
+	seg, dist := ir.tree.Nearest(point[0], point[1], func(i int) float64 {
+		_, d := closestPointOnSegment(point, ir.ls[i], ir.ls[i+1])
+		return d
+	})
+	if seg < 0 {
+		return Point{}, math.Inf(1)
+	}
+
+	nearest, _ := closestPointOnSegment(point, ir.ls[seg], ir.ls[seg+1])
+	return nearest, dist
+}
+
+func closestPointOnSegment(p, a, b Point) (Point, float64) {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	lenSq := dx*dx + dy*dy
+
+	if lenSq == 0 {
+		return a, a.DistanceFrom(p)
+	}
+
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / lenSq
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+
+	c := Point{a[0] + t*dx, a[1] + t*dy}
+	return c, c.DistanceFrom(p)
+}
+
+func segmentsIntersect(p1, p2, p3, p4 Point) bool {
+	return geom2d.SegmentsCrossOrTouch(
+		p1[0], p1[1], p2[0], p2[1], p3[0], p3[1], p4[0], p4[1])
+}
@@ -0,0 +1,118 @@
+package planar
+
+import "testing"
+
+// densifyRing inserts extraPerEdge extra collinear points along each edge
+// of a closed ring, so the result has enough points to exercise
+// IndexedPolygon's R-tree path without changing the shape it encloses.
+func densifyRing(ring LineString, extraPerEdge int) LineString {
+	out := make(LineString, 0, (len(ring)-1)*(extraPerEdge+1)+1)
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := ring[i], ring[i+1]
+		out = append(out, a)
+		for k := 1; k <= extraPerEdge; k++ {
+			t := float64(k) / float64(extraPerEdge+1)
+			out = append(out, Point{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])})
+		}
+	}
+	out = append(out, ring[len(ring)-1])
+	return out
+}
+
+func bigSquareRing(t *testing.T) LineString {
+	t.Helper()
+	square := LineString{{0, 0}, {100, 0}, {100, 100}, {0, 100}, {0, 0}}
+	ring := densifyRing(square, 10)
+	if len(ring) < indexedRingThreshold {
+		t.Fatalf("test ring has %d points, want >= %d to exercise the tree path", len(ring), indexedRingThreshold)
+	}
+	return ring
+}
+
+// TestIndexedPolygonContainsUsesTree checks Contains against a ring large
+// enough to be indexed with an R-tree, both for a point inside and one
+// outside.
+func TestIndexedPolygonContainsUsesTree(t *testing.T) {
+	ip := NewIndexedPolygon(Polygon{bigSquareRing(t)})
+
+	if !ip.Contains(Point{50, 50}) {
+		t.Fatal("Contains(50,50) = false, want true")
+	}
+	if ip.Contains(Point{150, 50}) {
+		t.Fatal("Contains(150,50) = true, want false")
+	}
+}
+
+// TestIndexedPolygonContainsSmallRingFallback checks Contains against a
+// ring below indexedRingThreshold, which must fall back to the plain
+// linear scan rather than building a tree.
+func TestIndexedPolygonContainsSmallRingFallback(t *testing.T) {
+	square := LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	if len(square) >= indexedRingThreshold {
+		t.Fatalf("test ring has %d points, want < %d to exercise the fallback path", len(square), indexedRingThreshold)
+	}
+
+	ip := NewIndexedPolygon(Polygon{square})
+
+	if !ip.Contains(Point{5, 5}) {
+		t.Fatal("Contains(5,5) = false, want true")
+	}
+	if ip.Contains(Point{20, 5}) {
+		t.Fatal("Contains(20,5) = true, want false")
+	}
+}
+
+// TestIndexedPolygonContainsWithHole checks that a point inside a hole is
+// not considered contained, and a point inside the shell but outside the
+// hole still is, using the large indexed shell from bigSquareRing.
+func TestIndexedPolygonContainsWithHole(t *testing.T) {
+	shell := bigSquareRing(t)
+	hole := LineString{{40, 40}, {40, 60}, {60, 60}, {60, 40}, {40, 40}}
+
+	ip := NewIndexedPolygon(Polygon{shell, hole})
+
+	if ip.Contains(Point{50, 50}) {
+		t.Fatal("Contains(50,50) = true, want false (inside the hole)")
+	}
+	if !ip.Contains(Point{10, 10}) {
+		t.Fatal("Contains(10,10) = false, want true (inside the shell, outside the hole)")
+	}
+}
+
+// TestIndexedPolygonIntersectsUsesTree checks Intersects against a large
+// indexed ring: a segment crossing the shell boundary must be reported,
+// one entirely outside must not.
+func TestIndexedPolygonIntersectsUsesTree(t *testing.T) {
+	ip := NewIndexedPolygon(Polygon{bigSquareRing(t)})
+
+	crossing := LineString{{50, -10}, {50, 10}}
+	if !ip.Intersects(crossing) {
+		t.Fatal("Intersects(crossing segment) = false, want true")
+	}
+
+	outside := LineString{{200, 200}, {300, 300}}
+	if ip.Intersects(outside) {
+		t.Fatal("Intersects(outside segment) = true, want false")
+	}
+}
+
+// TestIndexedPolygonIntersectsSmallRingFallback checks Intersects against
+// a ring below indexedRingThreshold, which must use the linear scan.
+func TestIndexedPolygonIntersectsSmallRingFallback(t *testing.T) {
+	square := LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	if len(square) >= indexedRingThreshold {
+		t.Fatalf("test ring has %d points, want < %d to exercise the fallback path", len(square), indexedRingThreshold)
+	}
+
+	ip := NewIndexedPolygon(Polygon{square})
+
+	crossing := LineString{{5, -5}, {5, 15}}
+	if !ip.Intersects(crossing) {
+		t.Fatal("Intersects(crossing segment) = false, want true")
+	}
+
+	outside := LineString{{20, 20}, {30, 30}}
+	if ip.Intersects(outside) {
+		t.Fatal("Intersects(outside segment) = true, want false")
+	}
+}
@@ -0,0 +1,39 @@
+package planar
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIndexedPolygonNearestEdgeTrueDistance builds a ring whose first edge
+// is a long diagonal: its bounding box touches the query point (bbox
+// distance 0) even though the edge itself passes far from it, while the
+// true nearest edge sits elsewhere in ring order. NearestEdge must return
+// the true nearest edge's distance, not whichever leaf has the smallest
+// bounding-box distance.
+func TestIndexedPolygonNearestEdgeTrueDistance(t *testing.T) {
+	ring := LineString{
+		{0, 100},
+		{100, 0}, // bbox [0,100]x[0,100] touches the origin; the segment
+		          // itself passes ~70.7 units from it
+	}
+	for i := 0; i < 30; i++ {
+		ring = append(ring, Point{1000 + float64(i), 1000})
+	}
+	ring = append(ring,
+		Point{1029, -5},
+		Point{-100, -5}, // the true nearest edge: runs along y=-5, 5 units from the origin
+	)
+	ring = append(ring, ring[0])
+
+	if len(ring) < indexedRingThreshold {
+		t.Fatalf("test ring has %d points, want >= %d to exercise the tree path", len(ring), indexedRingThreshold)
+	}
+
+	ip := NewIndexedPolygon(Polygon{ring})
+
+	_, dist := ip.NearestEdge(Point{0, 0})
+	if math.Abs(dist-5) > 1e-9 {
+		t.Fatalf("NearestEdge distance = %v, want 5", dist)
+	}
+}
@@ -0,0 +1,68 @@
+package overlay
+
+import "github.com/planetfederal/orb/planar"
+
+// selectEdges decides, for every segment fragment produced by the sweep,
+// whether it belongs in the result of op. Each fragment's membership in
+// the *other* polygon is tested directly via Polygon.Contains on its
+// midpoint (which, since the sweep already split every fragment at each
+// crossing, cannot straddle the other polygon's boundary). This sidesteps
+// maintaining in/out parity through the sweep itself, at the cost of one
+// point-in-polygon test per fragment.
+func selectEdges(segments []*segment, subject, clip planar.MultiPolygon, op Op) []*segment {
+	var selected []*segment
+
+	for _, s := range segments {
+		mid := planar.Point{(s.a[0] + s.b[0]) / 2, (s.a[1] + s.b[1]) / 2}
+
+		var inOther bool
+		if s.subject {
+			inOther = containsPoint(clip, mid)
+		} else {
+			inOther = containsPoint(subject, mid)
+		}
+
+		if keepEdge(op, s.subject, inOther) {
+			// a kept edge from inside the other polygon bounds area on its
+			// far side (a Difference hole, a SymDifference piece), so it is
+			// walked against its source ring's direction
+			if inOther && (op == SymDifference || (op == Difference && !s.subject)) {
+				s.reversed = !s.reversed
+			}
+			selected = append(selected, s)
+		}
+	}
+
+	return selected
+}
+
+func containsPoint(mp planar.MultiPolygon, p planar.Point) bool {
+	for _, poly := range mp {
+		if poly.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepEdge applies the standard overlay edge-selection rule: an edge
+// contributes to the result boundary when crossing it changes whether the
+// result contains that area, given which side of the *other* polygon it's
+// on.
+func keepEdge(op Op, isSubject, inOther bool) bool {
+	switch op {
+	case Union:
+		return !inOther
+	case Intersection:
+		return inOther
+	case Difference:
+		if isSubject {
+			return !inOther
+		}
+		return inOther
+	case SymDifference:
+		return true
+	default:
+		return false
+	}
+}
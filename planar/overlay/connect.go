@@ -0,0 +1,172 @@
+package overlay
+
+import "github.com/planetfederal/orb/planar"
+
+// connectEdges chains the selected edge fragments into closed rings by
+// following *directed* endpoint adjacency, then sorts those rings into
+// shells and holes (by containment) to rebuild a MultiPolygon.
+//
+// The adjacency is built from each segment's directed() endpoints, i.e.
+// the source ring's original a->b order, rather than its left-right
+// normalized a, b. That makes a traced ring's winding direction a
+// property of the input geometry, not of which point a map iteration
+// happens to pick as the trace's starting vertex.
+func connectEdges(segments []*segment) planar.MultiPolygon {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	remaining := map[planar.Point][]planar.Point{}
+	for _, s := range segments {
+		from, to := s.directed()
+		remaining[from] = append(remaining[from], to)
+	}
+
+	var rings []planar.LineString
+	for hasRemainingEdges(remaining) {
+		for _, ring := range traceRing(remaining) {
+			if len(ring) >= 4 {
+				rings = append(rings, ring)
+			}
+		}
+	}
+
+	return groupIntoPolygons(rings)
+}
+
+func hasRemainingEdges(remaining map[planar.Point][]planar.Point) bool {
+	for _, v := range remaining {
+		if len(v) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func traceRing(remaining map[planar.Point][]planar.Point) []planar.LineString {
+	var start planar.Point
+	for p, edges := range remaining {
+		if len(edges) > 0 {
+			start = p
+			break
+		}
+	}
+
+	var rings []planar.LineString
+	ring := planar.LineString{start}
+	current := start
+
+	for {
+		next, ok := popNeighbor(remaining, current)
+		if !ok {
+			// dead end: not a closed ring, stop where we are
+			break
+		}
+
+		ring = append(ring, next)
+		current = next
+
+		if current == start {
+			break
+		}
+
+		// back at a vertex already on the walk (two rings touching
+		// there): cut that loop off as a ring of its own
+		for k := 1; k < len(ring)-1; k++ {
+			if ring[k] == current {
+				rings = append(rings, append(planar.LineString(nil), ring[k:]...))
+				ring = ring[:k+1]
+				break
+			}
+		}
+	}
+
+	if ring[len(ring)-1] != start {
+		ring = append(ring, start)
+	}
+
+	return append(rings, ring)
+}
+
+func popNeighbor(remaining map[planar.Point][]planar.Point, p planar.Point) (planar.Point, bool) {
+	edges := remaining[p]
+	if len(edges) == 0 {
+		return planar.Point{}, false
+	}
+
+	next := edges[len(edges)-1]
+	remaining[p] = edges[:len(edges)-1]
+	return next, true
+}
+
+// groupIntoPolygons classifies every ring as a shell or a hole by how many
+// other rings contain it (an even count makes a shell), orients it per OGC
+// (shells CCW, holes CW) and assigns each hole to the smallest shell that
+// contains it.
+func groupIntoPolygons(rings []planar.LineString) planar.MultiPolygon {
+	var shells, holes []planar.LineString
+
+	for i, r := range rings {
+		probe := planar.Point{(r[0][0] + r[1][0]) / 2, (r[0][1] + r[1][1]) / 2}
+		depth := 0
+		for j, other := range rings {
+			if j != i && ringContains(other, probe) {
+				depth++
+			}
+		}
+
+		isShell := depth%2 == 0
+		if isShell != (signedArea(r) > 0) {
+			for a, b := 0, len(r)-1; a < b; a, b = a+1, b-1 {
+				r[a], r[b] = r[b], r[a]
+			}
+		}
+		if isShell {
+			shells = append(shells, r)
+		} else {
+			holes = append(holes, r)
+		}
+	}
+
+	result := make(planar.MultiPolygon, len(shells))
+	for i, shell := range shells {
+		result[i] = planar.Polygon{shell}
+	}
+
+	for _, hole := range holes {
+		best := -1
+		for i, shell := range shells {
+			if !ringContains(shell, hole[0]) {
+				continue
+			}
+			if best == -1 || absArea(shells[best]) > absArea(shell) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			result[best] = append(result[best], hole)
+		}
+	}
+
+	return result
+}
+
+func signedArea(ring planar.LineString) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+func absArea(ring planar.LineString) float64 {
+	a := signedArea(ring)
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func ringContains(ring planar.LineString, p planar.Point) bool {
+	return planar.Polygon{ring}.Contains(p)
+}
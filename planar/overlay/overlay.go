@@ -0,0 +1,46 @@
+// Package overlay implements 2D boolean set operations (union,
+// intersection, difference, symmetric difference) between planar
+// polygons, in the spirit of the Martinez-Rueda-Feito algorithm: edges
+// are split at every subject/clip crossing and then reselected according
+// to the requested op. It exists to unblock polygon combination
+// workflows that would otherwise require shelling out to PostGIS/GEOS.
+//
+// The implementation favors a working, pragmatic sweep over a fully exact
+// one: intersection points are computed in float64 rather than rational
+// arithmetic, so inputs with large amounts of exactly-collinear or
+// overlapping geometry may need a pre-pass through validate.MakeValid or a
+// coordinate snap before they sweep cleanly.
+package overlay
+
+import "github.com/planetfederal/orb/planar"
+
+// Op selects which boolean set operation to run.
+type Op int
+
+const (
+	// Union keeps area that is in either polygon.
+	Union Op = iota
+	// Intersection keeps area that is in both polygons.
+	Intersection
+	// Difference keeps area in the subject but not the clip.
+	Difference
+	// SymDifference keeps area in exactly one of the two polygons.
+	SymDifference
+)
+
+// Polygon runs op between a single subject and clip polygon.
+func Polygon(subject, clip planar.Polygon, op Op) planar.MultiPolygon {
+	return Polygons(planar.MultiPolygon{subject}, planar.MultiPolygon{clip}, op)
+}
+
+// Polygons runs op between a subject and clip MultiPolygon, returning the
+// result as a MultiPolygon (possibly empty if the operation produces no
+// area, e.g. a disjoint Intersection).
+func Polygons(subject, clip planar.MultiPolygon, op Op) planar.MultiPolygon {
+	subjectSegs := explodeRings(subject, true)
+	clipSegs := explodeRings(clip, false)
+	segments := sweep(subjectSegs, clipSegs)
+	selected := selectEdges(segments, subject, clip, op)
+
+	return connectEdges(selected)
+}
@@ -0,0 +1,75 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// TestPolygonsSubjectWithHole checks that selectEdges's containment test
+// respects a hole: a clip polygon sitting entirely inside the subject's
+// hole must be treated as outside the subject, not inside it.
+func TestPolygonsSubjectWithHole(t *testing.T) {
+	frame := planar.Polygon{
+		square(0, 0, 20, 20)[0],
+		square(5, 5, 15, 15)[0],
+	}
+	hole := square(7, 7, 13, 13)
+
+	const frameArea = 300 // 400 - 100
+	const holeArea = 36
+
+	if got := totalArea(Polygon(frame, hole, Union)); got != frameArea+holeArea {
+		t.Fatalf("Union area = %v, want %v", got, frameArea+holeArea)
+	}
+	if got := totalArea(Polygon(frame, hole, Intersection)); got != 0 {
+		t.Fatalf("Intersection area = %v, want 0 (clip sits entirely in subject's hole)", got)
+	}
+	if got := totalArea(Polygon(frame, hole, Difference)); got != frameArea {
+		t.Fatalf("Difference area = %v, want %v (clip doesn't overlap subject)", got, frameArea)
+	}
+}
+
+// TestPolygonsSharedEdge checks two squares that touch along exactly one
+// edge, rather than crossing: sweep must not treat the shared edge as a
+// crossing to split, and the result must still have zero intersection
+// area and the full combined area for the union.
+func TestPolygonsSharedEdge(t *testing.T) {
+	a := square(0, 0, 10, 10)
+	b := square(10, 0, 20, 10)
+
+	if got := totalArea(Polygon(a, b, Union)); got != 200 {
+		t.Fatalf("Union area = %v, want 200", got)
+	}
+	if got := totalArea(Polygon(a, b, Intersection)); got != 0 {
+		t.Fatalf("Intersection area = %v, want 0", got)
+	}
+}
+
+// TestPolygonsManyCrossings runs a plus-shaped concave subject against a
+// clip square that crosses its boundary many times across several of its
+// edges (not just the two crossings the overlapping-squares test
+// exercises), so the sweep needs more than one pass to settle.
+func TestPolygonsManyCrossings(t *testing.T) {
+	plus := planar.Polygon{planar.LineString{
+		{4, 0}, {6, 0}, {6, 4}, {10, 4}, {10, 6}, {6, 6},
+		{6, 10}, {4, 10}, {4, 6}, {0, 6}, {0, 4}, {4, 4}, {4, 0},
+	}}
+	clip := square(2, 3, 8, 7)
+
+	const wantIntersection = 16
+	const wantPlusArea = 36
+	const wantClipArea = 24
+	const wantUnion = wantPlusArea + wantClipArea - wantIntersection
+	const wantDifference = wantPlusArea - wantIntersection
+
+	if got := totalArea(Polygon(plus, clip, Intersection)); got != wantIntersection {
+		t.Fatalf("Intersection area = %v, want %v", got, wantIntersection)
+	}
+	if got := totalArea(Polygon(plus, clip, Union)); got != wantUnion {
+		t.Fatalf("Union area = %v, want %v", got, wantUnion)
+	}
+	if got := totalArea(Polygon(plus, clip, Difference)); got != wantDifference {
+		t.Fatalf("Difference area = %v, want %v", got, wantDifference)
+	}
+}
@@ -0,0 +1,88 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+func square(x0, y0, x1, y1 float64) planar.Polygon {
+	return planar.Polygon{planar.LineString{
+		{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}, {x0, y0},
+	}}
+}
+
+func totalArea(mp planar.MultiPolygon) float64 {
+	var total float64
+	for _, poly := range mp {
+		total += poly.Area()
+	}
+	return total
+}
+
+// TestPolygonsOverlappingSquares runs each op many times against the same
+// input: connectEdges picks its trace's starting vertex via map iteration
+// order, which Go randomizes per run, so a ring whose winding direction
+// (and therefore its shell/hole classification) depended on that order
+// would produce the wrong area on some fraction of runs.
+func TestPolygonsOverlappingSquares(t *testing.T) {
+	a := square(0, 0, 10, 10)
+	b := square(5, 5, 15, 15)
+
+	const wantUnion = 175 // 100 + 100 - 25 overlap
+	const wantIntersection = 25
+	const wantDifference = 75
+
+	for i := 0; i < 50; i++ {
+		if got := totalArea(Polygon(a, b, Union)); got != wantUnion {
+			t.Fatalf("run %d: Union area = %v, want %v", i, got, wantUnion)
+		}
+		if got := totalArea(Polygon(a, b, Intersection)); got != wantIntersection {
+			t.Fatalf("run %d: Intersection area = %v, want %v", i, got, wantIntersection)
+		}
+		if got := totalArea(Polygon(a, b, Difference)); got != wantDifference {
+			t.Fatalf("run %d: Difference area = %v, want %v", i, got, wantDifference)
+		}
+	}
+}
+
+// TestPolygonsOverlappingSquaresSymDifference checks the one op
+// TestPolygonsOverlappingSquares doesn't cover: the two non-overlapping
+// slivers of two overlapping squares, as two separate rings.
+func TestPolygonsOverlappingSquaresSymDifference(t *testing.T) {
+	a := square(0, 0, 10, 10)
+	b := square(5, 5, 15, 15)
+
+	const want = 150 // 175 union - 25 overlap counted twice
+
+	for i := 0; i < 50; i++ {
+		result := Polygon(a, b, SymDifference)
+		if len(result) != 2 {
+			t.Fatalf("run %d: got %d polygons, want 2", i, len(result))
+		}
+		if got := totalArea(result); got != want {
+			t.Fatalf("run %d: SymDifference area = %v, want %v", i, got, want)
+		}
+	}
+}
+
+// TestPolygonsDisjointSquaresUnion covers the case that tripped the old
+// implementation most reliably: two disjoint squares produce two
+// separate, unconnected rings, and a ring traced in the "wrong" direction
+// was misclassified as an unassigned hole and silently dropped.
+func TestPolygonsDisjointSquaresUnion(t *testing.T) {
+	a := square(0, 0, 10, 10)
+	b := square(20, 20, 30, 30)
+
+	const want = 200 // two disjoint 100-area squares
+
+	for i := 0; i < 50; i++ {
+		result := Polygon(a, b, Union)
+		if len(result) != 2 {
+			t.Fatalf("run %d: got %d polygons, want 2", i, len(result))
+		}
+		if got := totalArea(result); got != want {
+			t.Fatalf("run %d: Union area = %v, want %v", i, got, want)
+		}
+	}
+}
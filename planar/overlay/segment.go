@@ -0,0 +1,60 @@
+package overlay
+
+import "github.com/planetfederal/orb/planar"
+
+// segment is a single edge fragment produced while exploding the input
+// rings and, later, while splitting edges at intersection points found by
+// the sweep. a is always left of b: a.X < b.X, or a.X == b.X && a.Y < b.Y.
+// reversed records whether that normalization flipped the source ring's
+// original direction, so connectEdges can later rebuild rings that wind
+// the same way as the input (shells CCW, holes CW per OGC) instead of
+// however the undirected trace happens to walk them.
+type segment struct {
+	a, b     planar.Point
+	subject  bool // true if this fragment came from the subject polygon
+	reversed bool // true if a, b is the reverse of the source ring's a->b
+}
+
+func newSegment(p, q planar.Point, subject bool) *segment {
+	reversed := p[0] > q[0] || (p[0] == q[0] && p[1] > q[1])
+	if reversed {
+		p, q = q, p
+	}
+	return &segment{a: p, b: q, subject: subject, reversed: reversed}
+}
+
+func (s *segment) degenerate() bool {
+	return s.a == s.b
+}
+
+// directed returns the segment's endpoints in the source ring's original
+// a->b order, undoing the left-right normalization applied in newSegment.
+func (s *segment) directed() (from, to planar.Point) {
+	if s.reversed {
+		return s.b, s.a
+	}
+	return s.a, s.b
+}
+
+func sharesEndpoint(a, b *segment) bool {
+	return a.a == b.a || a.a == b.b || a.b == b.a || a.b == b.b
+}
+
+// explodeRings turns every ring of mp into left-to-right normalized
+// segments tagged with their source polygon.
+func explodeRings(mp planar.MultiPolygon, subject bool) []*segment {
+	var segments []*segment
+
+	for _, poly := range mp {
+		for _, ring := range poly {
+			for i := 0; i < len(ring)-1; i++ {
+				s := newSegment(ring[i], ring[i+1], subject)
+				if !s.degenerate() {
+					segments = append(segments, s)
+				}
+			}
+		}
+	}
+
+	return segments
+}
@@ -0,0 +1,122 @@
+package overlay
+
+import "github.com/planetfederal/orb/planar"
+
+// maxSplits bounds the number of segment splits performed before giving
+// up on subdividing further. It exists purely to guarantee termination
+// against float64 round-off on nearly-collinear input; well-formed
+// polygons never come close to it.
+const maxSplits = 1 << 16
+
+// maxPasses bounds the number of full re-scans sweep will run even when
+// each one keeps finding a split to make. maxSplits alone only bounds the
+// total number of splits, not the number of O(n*m) passes spent finding
+// them; a pathological input that splits just under that cap on every
+// pass would otherwise cost maxSplits full passes instead of a handful.
+// Well-formed polygons converge in a few passes at most.
+const maxPasses = 64
+
+// sweep finds every crossing between a subject edge and a clip edge and
+// subdivides both at the crossing point, repeating until a full pass
+// finds nothing left to split, so that every fragment afterwards lies
+// entirely inside or outside the other polygon. Edges from the same
+// polygon are never tested against each other: self-intersection is
+// planar/validate's job, not this one's.
+//
+// This scans every subject/clip pair rather than maintaining a sweep
+// status ordered by the sweep line, trading the O((n+k) log n) of a true
+// Bentley-Ottmann sweep for a simpler repeated O(n*m) pass that doesn't
+// depend on getting vertical-segment ordering right. A split can surface
+// a new crossing earlier in the slice than where it was appended (e.g.
+// the far fragment of an already-visited segment), so each pass re-scans
+// from the top rather than continuing where the previous one left off.
+// Fine for the polygon sizes this package targets; an ordered sweep
+// status would be the natural upgrade if profiling ever calls for it.
+func sweep(subjectSegs, clipSegs []*segment) []*segment {
+	all := make([]*segment, 0, len(subjectSegs)+len(clipSegs))
+	all = append(all, subjectSegs...)
+	all = append(all, clipSegs...)
+
+	splits := 0
+	for pass := 0; pass < maxPasses; pass++ {
+		changed := false
+		for i := 0; i < len(all); i++ {
+			for j := i + 1; j < len(all); j++ {
+				a, b := all[i], all[j]
+				if a.subject == b.subject || sharesEndpoint(a, b) {
+					continue
+				}
+
+				if splits >= maxSplits {
+					return all
+				}
+
+				pt, ok := intersectSegments(a, b)
+				if !ok {
+					continue
+				}
+
+				if aFar := splitInPlace(a, pt); aFar != nil {
+					all = append(all, aFar)
+					splits++
+					changed = true
+				}
+				if bFar := splitInPlace(b, pt); bFar != nil {
+					all = append(all, bFar)
+					splits++
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return all
+		}
+	}
+	return all
+}
+
+// splitInPlace truncates s to end at pt and returns the remaining
+// [pt, s.b] fragment as a new segment, or nil if pt is already one of
+// s's endpoints (nothing to split off).
+func splitInPlace(s *segment, pt planar.Point) *segment {
+	if pt == s.a || pt == s.b {
+		return nil
+	}
+
+	far := &segment{a: pt, b: s.b, subject: s.subject, reversed: s.reversed}
+	s.b = pt
+	return far
+}
+
+// intersectSegments returns the single intersection point of two
+// non-parallel segments, if it falls within both. Collinear overlaps
+// aren't split further; they're rare for well-formed input and are left
+// to a future exact-arithmetic pass.
+func intersectSegments(s1, s2 *segment) (planar.Point, bool) {
+	p, r := s1.a, sub(s1.b, s1.a)
+	qp, sdir := s2.a, sub(s2.b, s2.a)
+
+	rxs := crossVec(r, sdir)
+	if rxs == 0 {
+		return planar.Point{}, false // parallel or collinear
+	}
+
+	qmp := sub(qp, p)
+	t := crossVec(qmp, sdir) / rxs
+	u := crossVec(qmp, r) / rxs
+
+	const eps = 1e-9
+	if t < -eps || t > 1+eps || u < -eps || u > 1+eps {
+		return planar.Point{}, false
+	}
+
+	return planar.Point{p[0] + t*r[0], p[1] + t*r[1]}, true
+}
+
+func sub(a, b planar.Point) planar.Point {
+	return planar.Point{a[0] - b[0], a[1] - b[1]}
+}
+
+func crossVec(a, b planar.Point) float64 {
+	return a[0]*b[1] - a[1]*b[0]
+}
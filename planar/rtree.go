@@ -0,0 +1,18 @@
+package planar
+
+import "github.com/planetfederal/orb/internal/rtree"
+
+// rtreeBound is an axis-aligned bounding box used by the per-ring segment
+// index. It is intentionally separate from Rect so the index has no
+// dependency on the public bounding-box API.
+type rtreeBound = rtree.Bound
+
+func rtreeBoundOfSegment(a, b Point) rtreeBound {
+	return rtree.BoundOfSegment(a[0], a[1], b[0], b[1])
+}
+
+// newRTree bulk-loads an R-tree over the given segment bounds. bounds[i]
+// is assumed to describe the edge between ring points i and i+1.
+func newRTree(bounds []rtreeBound) *rtree.Tree {
+	return rtree.New(bounds)
+}
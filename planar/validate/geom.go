@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"github.com/planetfederal/orb/internal/geom2d"
+	"github.com/planetfederal/orb/planar"
+)
+
+// signedArea returns the shoelace area of ring, positive for CCW winding
+// and negative for CW, following the OGC convention used elsewhere in
+// this package (shells CCW, holes CW).
+func signedArea(ring planar.LineString) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+// ringContainsRing reports whether every point of inner lies within outer,
+// using a plain ray-cast per point. Good enough for validity checking;
+// planar.IndexedPolygon is the better tool for repeated containment queries.
+func ringContainsRing(outer, inner planar.LineString) bool {
+	poly := planar.Polygon{outer}
+	for i := 0; i < len(inner)-1; i++ {
+		if !poly.Contains(inner[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringsIntersect reports whether any edge of a crosses any edge of b.
+func ringsIntersect(a, b planar.LineString) bool {
+	for i := 0; i < len(a)-1; i++ {
+		for j := 0; j < len(b)-1; j++ {
+			if segmentsCross(a[i], a[i+1], b[j], b[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsCross reports whether segments p1-p2 and p3-p4 properly or
+// improperly intersect (including touching endpoints/collinear overlap).
+func segmentsCross(p1, p2, p3, p4 planar.Point) bool {
+	return geom2d.SegmentsCrossOrTouch(
+		p1[0], p1[1], p2[0], p2[1], p3[0], p3[1], p4[0], p4[1])
+}
+
+// intersectionPoint returns the point where segments p1-p2 and p3-p4
+// actually cross, using the same parametric line-intersection math as
+// planar/overlay/sweep.go's intersectSegments. It reports ok=false for
+// parallel/collinear segments or when the crossing isn't strictly inside
+// both segments, since a touch at an existing endpoint isn't a crossing
+// that needs a new vertex spliced in.
+func intersectionPoint(p1, p2, p3, p4 planar.Point) (pt planar.Point, ok bool) {
+	rx, ry := p2[0]-p1[0], p2[1]-p1[1]
+	sx, sy := p4[0]-p3[0], p4[1]-p3[1]
+
+	rxs := rx*sy - ry*sx
+	if rxs == 0 {
+		return planar.Point{}, false
+	}
+
+	qpx, qpy := p3[0]-p1[0], p3[1]-p1[1]
+	t := (qpx*sy - qpy*sx) / rxs
+	u := (qpx*ry - qpy*rx) / rxs
+
+	const eps = 1e-9
+	if t < eps || t > 1-eps || u < eps || u > 1-eps {
+		return planar.Point{}, false
+	}
+
+	return planar.Point{p1[0] + t*rx, p1[1] + t*ry}, true
+}
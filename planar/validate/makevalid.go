@@ -0,0 +1,170 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/planetfederal/orb"
+	"github.com/planetfederal/orb/planar"
+)
+
+// MakeValid repairs the common problems Validate reports: it closes open
+// rings, drops consecutive duplicate points, splits self-intersecting
+// rings at their intersection points, re-orients rings CCW/CW per OGC, and
+// drops holes that lie outside their shell or nest inside another hole.
+// The result is always returned as a MultiPolygon so a single input
+// polygon that splits into several pieces can still be represented.
+func MakeValid(g orb.Geometry) (planar.MultiPolygon, error) {
+	var polygons planar.MultiPolygon
+
+	switch v := g.(type) {
+	case planar.Polygon:
+		polygons = planar.MultiPolygon{v}
+	case planar.MultiPolygon:
+		polygons = v
+	default:
+		return nil, fmt.Errorf("validate: unsupported geometry type %T", g)
+	}
+
+	var result planar.MultiPolygon
+	for _, p := range polygons {
+		result = append(result, makeValidPolygon(p)...)
+	}
+
+	return result, nil
+}
+
+func makeValidPolygon(p planar.Polygon) planar.MultiPolygon {
+	if len(p) == 0 {
+		return nil
+	}
+
+	shells := splitSelfIntersections(cleanRing(p[0]))
+	if len(shells) == 0 {
+		return nil
+	}
+
+	var holes []planar.LineString
+	for i := 1; i < len(p); i++ {
+		holes = append(holes, splitSelfIntersections(cleanRing(p[i]))...)
+	}
+
+	// assign each cleaned hole to the (single, since shells themselves
+	// don't overlap after splitting) shell it falls inside, dropping any
+	// hole that escapes its shell or nests inside an earlier hole.
+	result := make(planar.MultiPolygon, len(shells))
+	for i, shell := range shells {
+		orient(&shell, true)
+		result[i] = planar.Polygon{shell}
+	}
+
+	for _, hole := range holes {
+		orient(&hole, false)
+
+		for i, shell := range shells {
+			if !ringContainsRing(shell, hole) {
+				continue
+			}
+
+			nested := false
+			for _, existing := range result[i][1:] {
+				if ringsIntersect(existing, hole) || ringContainsRing(existing, hole) || ringContainsRing(hole, existing) {
+					nested = true
+					break
+				}
+			}
+			if !nested {
+				result[i] = append(result[i], hole)
+			}
+			break
+		}
+	}
+
+	return result
+}
+
+// cleanRing closes the ring if needed and removes consecutive duplicate
+// points, dropping the ring entirely if it can't reach 4 points.
+func cleanRing(ring planar.LineString) planar.LineString {
+	if len(ring) == 0 {
+		return nil
+	}
+
+	cleaned := planar.LineString{ring[0]}
+	for i := 1; i < len(ring); i++ {
+		if ring[i] != cleaned[len(cleaned)-1] {
+			cleaned = append(cleaned, ring[i])
+		}
+	}
+
+	if cleaned[0] != cleaned[len(cleaned)-1] {
+		cleaned = append(cleaned, cleaned[0])
+	}
+
+	if len(cleaned) < 4 {
+		return nil
+	}
+
+	return cleaned
+}
+
+// splitSelfIntersections repeatedly finds the first self-intersection in
+// ring and cuts it into two closed rings at the actual crossing point,
+// recursing on each half, until every resulting ring is simple.
+// Degenerate slivers below 4 points are discarded.
+func splitSelfIntersections(ring planar.LineString) []planar.LineString {
+	if ring == nil {
+		return nil
+	}
+
+	n := len(ring) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+
+			pt, ok := intersectionPoint(ring[i], ring[i+1], ring[j], ring[j+1])
+			if !ok {
+				continue
+			}
+
+			// cut the ring into [pt, i+1..j, pt] and [pt, j+1..i, pt], each
+			// closed back on itself at the shared intersection point, which
+			// becomes a real vertex of both halves rather than a splice
+			// between whatever existing indices happened to bracket it.
+			var a, b planar.LineString
+			a = append(a, pt)
+			a = append(a, ring[i+1:j+1]...)
+			a = append(a, pt)
+
+			b = append(b, pt)
+			b = append(b, ring[j+1:n]...)
+			b = append(b, ring[:i+1]...)
+			b = append(b, pt)
+
+			var out []planar.LineString
+			if c := cleanRing(a); c != nil {
+				out = append(out, splitSelfIntersections(c)...)
+			}
+			if c := cleanRing(b); c != nil {
+				out = append(out, splitSelfIntersections(c)...)
+			}
+			return out
+		}
+	}
+
+	return []planar.LineString{ring}
+}
+
+// orient flips ring in place so its winding matches ccw.
+func orient(ring *planar.LineString, ccw bool) {
+	isCCW := signedArea(*ring) > 0
+	if isCCW == ccw {
+		return
+	}
+
+	r := *ring
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+}
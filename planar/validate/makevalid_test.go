@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// TestMakeValidBowtieSplitsAtTrueIntersection checks the textbook
+// self-intersecting polygon: a ring that crosses itself like an hourglass.
+// The repair must cut it at the actual geometric crossing point rather
+// than between whatever existing vertex indices bracket it, or both
+// halves collapse to degenerate 3-point rings and get dropped.
+func TestMakeValidBowtieSplitsAtTrueIntersection(t *testing.T) {
+	bowtie := planar.Polygon{planar.LineString{
+		{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0},
+	}}
+
+	result, err := MakeValid(bowtie)
+	if err != nil {
+		t.Fatalf("MakeValid returned error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("got %d polygons, want 2 (one triangle per half of the bowtie)", len(result))
+	}
+
+	var total float64
+	for _, p := range result {
+		total += p.Area()
+	}
+
+	// each half is a triangle with base 10 and height 5 (area 25), the two
+	// meeting at the (5,5) crossing point.
+	const want = 50
+	if total < want-0.1 || total > want+0.1 {
+		t.Fatalf("total area = %v, want ~%v (two 25-area triangles)", total, want)
+	}
+}
@@ -0,0 +1,165 @@
+// Package validate implements OGC-style validity checking and repair for
+// planar polygons: self-intersection, ring closure, hole orientation,
+// hole-outside-shell and nested-hole detection, and duplicate consecutive
+// points. planar.Polygon's own methods (Area, Centroid, Contains) assume
+// valid input and will silently produce wrong answers otherwise, so callers
+// that build polygons from untrusted data should run Validate first.
+package validate
+
+import (
+	"fmt"
+
+	"github.com/planetfederal/orb"
+	"github.com/planetfederal/orb/planar"
+)
+
+// IssueType classifies the kind of validity problem found.
+type IssueType int
+
+const (
+	// SelfIntersection means two non-adjacent edges of the same ring cross.
+	SelfIntersection IssueType = iota
+	// RingNotClosed means a ring's first and last points do not match.
+	RingNotClosed
+	// TooFewPoints means a ring has fewer than 4 points (3 distinct plus closure).
+	TooFewPoints
+	// WrongOrientation means a shell is not CCW, or a hole is not CW.
+	WrongOrientation
+	// HoleOutsideShell means a hole ring is not contained by its shell.
+	HoleOutsideShell
+	// NestedHoles means two holes of the same polygon overlap each other.
+	NestedHoles
+	// DuplicatePoints means a ring has consecutive repeated points.
+	DuplicatePoints
+)
+
+func (t IssueType) String() string {
+	switch t {
+	case SelfIntersection:
+		return "self-intersection"
+	case RingNotClosed:
+		return "ring not closed"
+	case TooFewPoints:
+		return "too few points"
+	case WrongOrientation:
+		return "wrong orientation"
+	case HoleOutsideShell:
+		return "hole outside shell"
+	case NestedHoles:
+		return "nested holes"
+	case DuplicatePoints:
+		return "duplicate consecutive points"
+	default:
+		return "unknown"
+	}
+}
+
+// Issue describes a single validity problem found in a polygon, identifying
+// the offending ring and, where applicable, the point or edge indices
+// within that ring.
+type Issue struct {
+	Type    IssueType
+	Polygon int // index into the MultiPolygon, 0 for a bare Polygon
+	Ring    int // 0 is the shell, >0 are holes
+	Point   int // index of the offending point, -1 if not point-specific
+	Other   int // the other ring/point involved, e.g. for NestedHoles/HoleOutsideShell
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: polygon %d, ring %d, point %d", i.Type, i.Polygon, i.Ring, i.Point)
+}
+
+// Validate runs OGC-style validity checks on g, which must be a
+// planar.Polygon or planar.MultiPolygon. It returns every issue found; a
+// nil/empty slice means g is valid. The error return is non-nil only for
+// unsupported geometry types.
+func Validate(g orb.Geometry) ([]Issue, error) {
+	switch v := g.(type) {
+	case planar.Polygon:
+		return validatePolygon(v, 0), nil
+	case planar.MultiPolygon:
+		var issues []Issue
+		for i, p := range v {
+			issues = append(issues, validatePolygon(p, i)...)
+		}
+		return issues, nil
+	default:
+		return nil, fmt.Errorf("validate: unsupported geometry type %T", g)
+	}
+}
+
+func validatePolygon(p planar.Polygon, polyIndex int) []Issue {
+	var issues []Issue
+
+	for ringIndex, ring := range p {
+		issues = append(issues, checkRing(ring, polyIndex, ringIndex)...)
+	}
+
+	if len(p) == 0 {
+		return issues
+	}
+
+	shellCCW := signedArea(p[0]) > 0
+	if !shellCCW {
+		issues = append(issues, Issue{Type: WrongOrientation, Polygon: polyIndex, Ring: 0, Point: -1})
+	}
+
+	for i := 1; i < len(p); i++ {
+		if signedArea(p[i]) > 0 {
+			issues = append(issues, Issue{Type: WrongOrientation, Polygon: polyIndex, Ring: i, Point: -1})
+		}
+
+		if !ringContainsRing(p[0], p[i]) {
+			issues = append(issues, Issue{Type: HoleOutsideShell, Polygon: polyIndex, Ring: i, Point: -1, Other: 0})
+		}
+
+		for j := i + 1; j < len(p); j++ {
+			if ringsIntersect(p[i], p[j]) || ringContainsRing(p[i], p[j]) || ringContainsRing(p[j], p[i]) {
+				issues = append(issues, Issue{Type: NestedHoles, Polygon: polyIndex, Ring: i, Point: -1, Other: j})
+			}
+		}
+	}
+
+	return issues
+}
+
+func checkRing(ring planar.LineString, polyIndex, ringIndex int) []Issue {
+	var issues []Issue
+
+	if len(ring) == 0 {
+		return issues
+	}
+
+	if ring[0] != ring[len(ring)-1] {
+		issues = append(issues, Issue{Type: RingNotClosed, Polygon: polyIndex, Ring: ringIndex, Point: len(ring) - 1})
+	}
+
+	if len(ring) < 4 {
+		issues = append(issues, Issue{Type: TooFewPoints, Polygon: polyIndex, Ring: ringIndex, Point: -1})
+	}
+
+	for i := 1; i < len(ring); i++ {
+		if ring[i] == ring[i-1] {
+			issues = append(issues, Issue{Type: DuplicatePoints, Polygon: polyIndex, Ring: ringIndex, Point: i})
+		}
+	}
+
+	// brute-force check every pair of non-adjacent edges; good enough for
+	// the ring sizes this package is meant for, a sweep-line pass would be
+	// the next step if this ever shows up in a profile.
+	n := len(ring) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				// these two edges share the closing vertex, not a crossing
+				continue
+			}
+
+			if segmentsCross(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				issues = append(issues, Issue{Type: SelfIntersection, Polygon: polyIndex, Ring: ringIndex, Point: i, Other: j})
+			}
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+func hasIssue(issues []Issue, typ IssueType) bool {
+	for _, iss := range issues {
+		if iss.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// TestValidateValidPolygonHasNoIssues checks that a well-formed shell with
+// a single interior hole reports no issues at all.
+func TestValidateValidPolygonHasNoIssues(t *testing.T) {
+	shell := planar.LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	hole := planar.LineString{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}
+
+	issues, err := Validate(planar.Polygon{shell, hole})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("got issues %v, want none", issues)
+	}
+}
+
+// TestValidateSelfIntersection checks the bowtie ring used elsewhere in
+// this package is flagged as self-intersecting.
+func TestValidateSelfIntersection(t *testing.T) {
+	bowtie := planar.Polygon{planar.LineString{
+		{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0},
+	}}
+
+	issues, err := Validate(bowtie)
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, SelfIntersection) {
+		t.Fatalf("got %v, want a SelfIntersection issue", issues)
+	}
+}
+
+// TestValidateRingNotClosed checks a ring whose first and last points
+// differ is flagged, independent of any other issue.
+func TestValidateRingNotClosed(t *testing.T) {
+	ring := planar.LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	issues, err := Validate(planar.Polygon{ring})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, RingNotClosed) {
+		t.Fatalf("got %v, want a RingNotClosed issue", issues)
+	}
+}
+
+// TestValidateTooFewPoints checks a closed ring with only 3 points (2
+// distinct plus closure) is flagged.
+func TestValidateTooFewPoints(t *testing.T) {
+	ring := planar.LineString{{0, 0}, {10, 0}, {0, 0}}
+
+	issues, err := Validate(planar.Polygon{ring})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, TooFewPoints) {
+		t.Fatalf("got %v, want a TooFewPoints issue", issues)
+	}
+}
+
+// TestValidateWrongOrientation checks a shell wound clockwise is flagged,
+// since shells must be CCW.
+func TestValidateWrongOrientation(t *testing.T) {
+	cwShell := planar.LineString{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}
+
+	issues, err := Validate(planar.Polygon{cwShell})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, WrongOrientation) {
+		t.Fatalf("got %v, want a WrongOrientation issue", issues)
+	}
+}
+
+// TestValidateHoleOutsideShell checks a hole that sits entirely outside
+// its shell is flagged.
+func TestValidateHoleOutsideShell(t *testing.T) {
+	shell := planar.LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	outsideHole := planar.LineString{{20, 20}, {20, 22}, {22, 22}, {22, 20}, {20, 20}}
+
+	issues, err := Validate(planar.Polygon{shell, outsideHole})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, HoleOutsideShell) {
+		t.Fatalf("got %v, want a HoleOutsideShell issue", issues)
+	}
+}
+
+// TestValidateNestedHolesIntersecting checks two holes whose boundaries
+// cross are flagged as NestedHoles.
+func TestValidateNestedHolesIntersecting(t *testing.T) {
+	shell := planar.LineString{{0, 0}, {20, 0}, {20, 20}, {0, 20}, {0, 0}}
+	holeA := planar.LineString{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}}
+	holeB := planar.LineString{{5, 5}, {5, 11}, {11, 11}, {11, 5}, {5, 5}}
+
+	issues, err := Validate(planar.Polygon{shell, holeA, holeB})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, NestedHoles) {
+		t.Fatalf("got %v, want a NestedHoles issue", issues)
+	}
+}
+
+// TestValidateNestedHolesPureContainment checks the case ringsIntersect
+// alone can't catch: one hole entirely inside another, with no edges
+// crossing at all. This is the regression case for the ringContainsRing
+// addition to the NestedHoles check.
+func TestValidateNestedHolesPureContainment(t *testing.T) {
+	shell := planar.LineString{{0, 0}, {20, 0}, {20, 20}, {0, 20}, {0, 0}}
+	outerHole := planar.LineString{{2, 2}, {2, 12}, {12, 12}, {12, 2}, {2, 2}}
+	innerHole := planar.LineString{{4, 4}, {4, 6}, {6, 6}, {6, 4}, {4, 4}}
+
+	issues, err := Validate(planar.Polygon{shell, outerHole, innerHole})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, NestedHoles) {
+		t.Fatalf("got %v, want a NestedHoles issue for the fully-contained hole", issues)
+	}
+}
+
+// TestValidateDuplicatePoints checks a ring with a repeated consecutive
+// point is flagged.
+func TestValidateDuplicatePoints(t *testing.T) {
+	ring := planar.LineString{{0, 0}, {10, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+
+	issues, err := Validate(planar.Polygon{ring})
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if !hasIssue(issues, DuplicatePoints) {
+		t.Fatalf("got %v, want a DuplicatePoints issue", issues)
+	}
+}
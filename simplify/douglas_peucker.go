@@ -0,0 +1,85 @@
+package simplify
+
+import (
+	"github.com/planetfederal/orb"
+	"github.com/planetfederal/orb/planar"
+)
+
+// DouglasPeucker simplifies geometry by recursively dropping, per ring,
+// the points that fall within Threshold of the line connecting the two
+// ends of the range being simplified.
+type DouglasPeucker struct {
+	Threshold float64
+}
+
+// NewDouglasPeucker returns a DouglasPeucker simplifier for the given
+// threshold, in the same units as the geometry's coordinates.
+func NewDouglasPeucker(threshold float64) *DouglasPeucker {
+	return &DouglasPeucker{Threshold: threshold}
+}
+
+// Simplify reduces g, which must be a planar.LineString, planar.Polygon or
+// planar.MultiPolygon.
+func (s *DouglasPeucker) Simplify(g orb.Geometry) (interface{}, error) {
+	switch v := g.(type) {
+	case planar.LineString:
+		return douglasPeuckerRing(v, s.Threshold), nil
+	case planar.Polygon:
+		return douglasPeuckerPolygon(v, s.Threshold), nil
+	case planar.MultiPolygon:
+		result := make(planar.MultiPolygon, len(v))
+		for i, p := range v {
+			result[i] = douglasPeuckerPolygon(p, s.Threshold)
+		}
+		return result, nil
+	default:
+		return nil, errUnsupported(g)
+	}
+}
+
+func douglasPeuckerPolygon(p planar.Polygon, threshold float64) planar.Polygon {
+	out := make(planar.Polygon, len(p))
+	for i, ring := range p {
+		out[i] = douglasPeuckerRing(ring, threshold)
+	}
+	return out
+}
+
+func douglasPeuckerRing(ring planar.LineString, threshold float64) planar.LineString {
+	if len(ring) < 3 {
+		return ring
+	}
+
+	keep := make([]bool, len(ring))
+	keep[0] = true
+	keep[len(ring)-1] = true
+	douglasPeuckerRange(ring, 0, len(ring)-1, threshold, keep)
+
+	out := make(planar.LineString, 0, len(ring))
+	for i, k := range keep {
+		if k {
+			out = append(out, ring[i])
+		}
+	}
+	return out
+}
+
+func douglasPeuckerRange(ring planar.LineString, lo, hi int, threshold float64, keep []bool) {
+	if hi <= lo+1 {
+		return
+	}
+
+	maxDist, maxIdx := -1.0, -1
+	for i := lo + 1; i < hi; i++ {
+		d := perpendicularDistance(ring[i], ring[lo], ring[hi])
+		if d > maxDist {
+			maxDist, maxIdx = d, i
+		}
+	}
+
+	if maxDist > threshold {
+		keep[maxIdx] = true
+		douglasPeuckerRange(ring, lo, maxIdx, threshold, keep)
+		douglasPeuckerRange(ring, maxIdx, hi, threshold, keep)
+	}
+}
@@ -0,0 +1,41 @@
+package simplify
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+// TestDouglasPeuckerRemovesPointWithinThreshold checks the straightforward
+// case: a single point that sits within threshold of the line through its
+// two neighbors is dropped.
+func TestDouglasPeuckerRemovesPointWithinThreshold(t *testing.T) {
+	line := planar.LineString{{0, 0}, {5, 0.1}, {10, 0}}
+
+	result, err := NewDouglasPeucker(1).Simplify(line)
+	if err != nil {
+		t.Fatalf("Simplify returned error: %v", err)
+	}
+
+	got := result.(planar.LineString)
+	want := planar.LineString{{0, 0}, {10, 0}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestDouglasPeuckerKeepsPointBeyondThreshold checks that a point well
+// off the line through its neighbors survives simplification.
+func TestDouglasPeuckerKeepsPointBeyondThreshold(t *testing.T) {
+	line := planar.LineString{{0, 0}, {5, 5}, {10, 0}}
+
+	result, err := NewDouglasPeucker(1).Simplify(line)
+	if err != nil {
+		t.Fatalf("Simplify returned error: %v", err)
+	}
+
+	got := result.(planar.LineString)
+	if len(got) != 3 || got[1] != (planar.Point{5, 5}) {
+		t.Fatalf("got %v, want the middle point kept", got)
+	}
+}
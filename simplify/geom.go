@@ -0,0 +1,36 @@
+package simplify
+
+import (
+	"math"
+
+	"github.com/planetfederal/orb/internal/geom2d"
+	"github.com/planetfederal/orb/planar"
+)
+
+// perpendicularDistance returns the distance from p to the line through a
+// and b (not the segment), which is what Douglas-Peucker compares against
+// its threshold.
+func perpendicularDistance(p, a, b planar.Point) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return a.DistanceFrom(p)
+	}
+
+	num := math.Abs(dy*p[0] - dx*p[1] + b[0]*a[1] - b[1]*a[0])
+	return num / math.Hypot(dx, dy)
+}
+
+func pointInTriangle(p, a, b, c planar.Point) bool {
+	return geom2d.PointInTriangle(
+		a[0], a[1], b[0], b[1], c[0], c[1], p[0], p[1])
+}
+
+// segmentsCross reports whether p1-p2 and p3-p4 properly cross. Unlike
+// planar.validate's segmentsCross, touching endpoints and collinear
+// overlap don't count: removalOK already skips edges that share an
+// endpoint with the shortcut before calling this, so anything left is
+// either a real crossing or unrelated.
+func segmentsCross(p1, p2, p3, p4 planar.Point) bool {
+	return geom2d.SegmentsCross(
+		p1[0], p1[1], p2[0], p2[1], p3[0], p3[1], p4[0], p4[1])
+}
@@ -0,0 +1,14 @@
+// Package simplify reduces the number of points in planar geometry while
+// keeping its shape, via Douglas-Peucker and a topology-preserving
+// variant for polygons.
+package simplify
+
+import (
+	"fmt"
+
+	"github.com/planetfederal/orb"
+)
+
+func errUnsupported(g orb.Geometry) error {
+	return fmt.Errorf("simplify: unsupported geometry type %T", g)
+}
@@ -0,0 +1,180 @@
+package simplify
+
+import (
+	"github.com/planetfederal/orb"
+	"github.com/planetfederal/orb/internal/rtree"
+	"github.com/planetfederal/orb/planar"
+)
+
+// TopologyPreserving simplifies a MultiPolygon ring by ring, removing one
+// vertex at a time when it lies within Threshold of the line through its
+// current neighbors, but rejects any candidate vertex removal that would
+// introduce a self-intersection, let a ring cross a sibling ring, or
+// collapse a ring below 4 points. Candidate edges are checked against
+// every other ring of the polygon, so a MultiPolygon with many rings
+// simplifies safely even when its holes sit close to each other or to the
+// shell. Unlike DouglasPeucker, a removed vertex is not measured again
+// against the edges that later replace its neighbors, so the result is not
+// kept within Threshold of the input ring.
+type TopologyPreserving struct {
+	Threshold float64
+}
+
+// NewTopologyPreserving returns a topology-preserving simplifier for the
+// given threshold, in the same units as the geometry's coordinates.
+func NewTopologyPreserving(threshold float64) *TopologyPreserving {
+	return &TopologyPreserving{Threshold: threshold}
+}
+
+// Simplify reduces g, which must be a planar.Polygon or planar.MultiPolygon.
+// Unlike DouglasPeucker, bare LineStrings aren't accepted: topology
+// preservation only means something across a polygon's rings.
+func (s *TopologyPreserving) Simplify(g orb.Geometry) (interface{}, error) {
+	switch v := g.(type) {
+	case planar.Polygon:
+		return simplifyPolygonTopology(v, s.Threshold), nil
+	case planar.MultiPolygon:
+		result := make(planar.MultiPolygon, len(v))
+		for i, p := range v {
+			result[i] = simplifyPolygonTopology(p, s.Threshold)
+		}
+		return result, nil
+	default:
+		return nil, errUnsupported(g)
+	}
+}
+
+func simplifyPolygonTopology(p planar.Polygon, threshold float64) planar.Polygon {
+	rings := make([]planar.LineString, len(p))
+	for i, ring := range p {
+		rings[i] = append(planar.LineString(nil), ring...)
+	}
+
+	for i := range rings {
+		simplifyRingTopology(rings, i, threshold)
+	}
+
+	return planar.Polygon(rings)
+}
+
+// simplifyRingTopology repeatedly scans rings[ringIdx] for a removable
+// vertex, applying the first safe one found and restarting, until a full
+// pass removes nothing. Each pass rebuilds an R-tree over every ring's
+// current vertices and edges once, rather than scanning them from
+// scratch for every candidate: a pass with c candidates and n total
+// vertices costs O(n log n) to build plus O(c log n) to check, not the
+// O(n) per candidate (O(n^2) per pass) of a plain linear scan.
+func simplifyRingTopology(rings []planar.LineString, ringIdx int, threshold float64) {
+	for {
+		ring := rings[ringIdx]
+		if len(ring) <= 4 {
+			return
+		}
+
+		index := newTopologyIndex(rings)
+
+		removedAny := false
+		for i := 1; i < len(ring)-1; i++ {
+			prev, curr, next := ring[i-1], ring[i], ring[i+1]
+
+			if perpendicularDistance(curr, prev, next) > threshold {
+				continue
+			}
+
+			candidate := make(planar.LineString, 0, len(ring)-1)
+			candidate = append(candidate, ring[:i]...)
+			candidate = append(candidate, ring[i+1:]...)
+
+			rings[ringIdx] = candidate
+			if index.removalOK(prev, curr, next) {
+				removedAny = true
+				break
+			}
+			rings[ringIdx] = ring
+		}
+
+		if !removedAny {
+			return
+		}
+	}
+}
+
+// topologyIndex is an R-tree of every ring's current vertices and edges,
+// keyed by bounding box, so removalOK's two checks (a vertex inside
+// the candidate triangle, an edge crossing the candidate shortcut) only
+// examine the handful of items whose bound overlaps the query instead of
+// every vertex and edge in the polygon.
+type topologyIndex struct {
+	points    []planar.Point
+	pointTree *rtree.Tree
+	edgeA     []planar.Point
+	edgeB     []planar.Point
+	edgeTree  *rtree.Tree
+}
+
+func newTopologyIndex(rings []planar.LineString) *topologyIndex {
+	idx := &topologyIndex{}
+
+	var pointBounds []rtree.Bound
+	var edgeBounds []rtree.Bound
+	for _, ring := range rings {
+		for _, v := range ring {
+			idx.points = append(idx.points, v)
+			pointBounds = append(pointBounds, rtree.BoundOfSegment(v[0], v[1], v[0], v[1]))
+		}
+		for e := 0; e < len(ring)-1; e++ {
+			a, b := ring[e], ring[e+1]
+			idx.edgeA = append(idx.edgeA, a)
+			idx.edgeB = append(idx.edgeB, b)
+			edgeBounds = append(edgeBounds, rtree.BoundOfSegment(a[0], a[1], b[0], b[1]))
+		}
+	}
+
+	idx.pointTree = rtree.New(pointBounds)
+	idx.edgeTree = rtree.New(edgeBounds)
+	return idx
+}
+
+// removalOK reports whether removing curr (replacing prev-curr-next with
+// the direct edge prev-next) keeps the polygon valid: the triangle
+// prev-curr-next must not contain a vertex of any ring, and the new edge
+// must not cross a retained edge of any ring.
+func (idx *topologyIndex) removalOK(prev, curr, next planar.Point) bool {
+	triBound := rtree.BoundOfSegment(prev[0], prev[1], next[0], next[1])
+	triBound = triBound.Expand(rtree.BoundOfSegment(curr[0], curr[1], curr[0], curr[1]))
+
+	ok := true
+	idx.pointTree.Search(triBound, func(i int) {
+		if !ok {
+			return
+		}
+		v := idx.points[i]
+		if v == prev || v == next || v == curr {
+			return
+		}
+		if pointInTriangle(v, prev, curr, next) {
+			ok = false
+		}
+	})
+	if !ok {
+		return false
+	}
+
+	shortcutBound := rtree.BoundOfSegment(prev[0], prev[1], next[0], next[1])
+	idx.edgeTree.Search(shortcutBound, func(i int) {
+		if !ok {
+			return
+		}
+		a, b := idx.edgeA[i], idx.edgeB[i]
+		if a == prev || a == next || b == prev || b == next {
+			// shares an endpoint with the shortcut edge: adjacent, not a
+			// crossing
+			return
+		}
+		if segmentsCross(prev, next, a, b) {
+			ok = false
+		}
+	})
+
+	return ok
+}
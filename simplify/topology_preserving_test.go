@@ -0,0 +1,67 @@
+package simplify
+
+import (
+	"testing"
+
+	"github.com/planetfederal/orb/planar"
+)
+
+func ringHasPoint(ring planar.LineString, p planar.Point) bool {
+	for _, v := range ring {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// TestTopologyPreservingRemovesUnconstrainedPeak checks the baseline:
+// with no other ring nearby, a vertex within threshold of the line
+// through its neighbors is removed, same as plain DouglasPeucker would.
+func TestTopologyPreservingRemovesUnconstrainedPeak(t *testing.T) {
+	peak := planar.Point{10, 6}
+	shell := planar.Polygon{planar.LineString{
+		{0, 0}, peak, {20, 0}, {20, 20}, {0, 20}, {0, 0},
+	}}
+
+	result, err := NewTopologyPreserving(10).Simplify(shell)
+	if err != nil {
+		t.Fatalf("Simplify returned error: %v", err)
+	}
+
+	got := result.(planar.Polygon)
+	if ringHasPoint(got[0], peak) {
+		t.Fatalf("peak vertex %v still present, want it removed", peak)
+	}
+}
+
+// TestTopologyPreservingRejectsRemovalCrossingSiblingRing checks the
+// actual topology-preserving guarantee: the same peak as above is within
+// threshold of its neighbors' line, but removing it would make the
+// straightened edge cross a second ring of the same polygon that the
+// original, unsimplified edges didn't cross. The removal must be
+// rejected and the vertex kept.
+func TestTopologyPreservingRejectsRemovalCrossingSiblingRing(t *testing.T) {
+	peak := planar.Point{10, 6}
+	shell := planar.LineString{
+		{0, 0}, peak, {20, 0}, {20, 20}, {0, 20}, {0, 0},
+	}
+	// straddles y=0 right under the peak: the bent edges pass above it
+	// (peak height 6 keeps x=9..11 above y=5), but the straightened
+	// shortcut at y=0 runs right through it.
+	sibling := planar.LineString{
+		{9, -5}, {11, -5}, {11, 5}, {9, 5}, {9, -5},
+	}
+
+	poly := planar.Polygon{shell, sibling}
+
+	result, err := NewTopologyPreserving(10).Simplify(poly)
+	if err != nil {
+		t.Fatalf("Simplify returned error: %v", err)
+	}
+
+	got := result.(planar.Polygon)
+	if !ringHasPoint(got[0], peak) {
+		t.Fatalf("peak vertex %v was removed, want it kept since the shortcut would cross the sibling ring", peak)
+	}
+}